@@ -0,0 +1,512 @@
+package version
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBaseURL is the base URL of the site that publishes the stable.txt and latest.txt files.
+const DefaultBaseURL = "https://docs.k0sproject.io"
+
+// DefaultAPIURL is the base URL of the GitHub API endpoints of the k0s repository, used for
+// listing releases.
+const DefaultAPIURL = "https://api.github.com/repos/k0sproject/k0s"
+
+// DefaultCacheMaxAge is how long a client reuses the release list returned by Releases before
+// fetching it again.
+const DefaultCacheMaxAge = 5 * time.Minute
+
+// channelNameRegex matches release channel names such as "stable", "latest" or "stable-1.29".
+var channelNameRegex = regexp.MustCompile(`^([a-z]+)(?:-(\d+\.\d+))?$`)
+
+// Client fetches k0s version information from the online sources. The zero value is not usable,
+// use NewClient to create one.
+type Client struct {
+	baseURL    string
+	apiURL     string
+	httpClient *http.Client
+	timeout    time.Duration
+	tlsConfig  *tls.Config
+	observer   func(req *http.Request, resp *http.Response, err error, elapsed time.Duration)
+	offline    bool
+	token      TokenSource
+	maxAge     time.Duration
+	maxPages   int
+	minVersion *Version
+	releases   *releaseCache
+	rateLimit  *rateLimitState
+	err        error
+}
+
+// ClientOption is a functional option for NewClient.
+type ClientOption func(*Client)
+
+// WithBaseURL sets the base URL of the site that publishes the stable.txt and latest.txt files.
+func WithBaseURL(u string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(u, "/")
+	}
+}
+
+// WithAPIURL sets the base URL of the GitHub API endpoints of the k0s repository, used for listing
+// releases (eg https://api.github.com/repos/k0sproject/k0s).
+func WithAPIURL(u string) ClientOption {
+	return func(c *Client) {
+		c.apiURL = strings.TrimSuffix(u, "/")
+	}
+}
+
+// WithHTTPClient sets the http.Client used for requests. When set, WithTimeout and WithTLSClientConfig
+// are ignored.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithTimeout sets the timeout for HTTP requests.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithTLSClientConfig sets the TLS configuration used for HTTPS connections. The configuration is
+// applied to a clone of http.DefaultTransport. If http.DefaultTransport has been replaced with
+// something else than an *http.Transport, the client's requests fail instead of silently ignoring
+// the configuration.
+func WithTLSClientConfig(cfg *tls.Config) ClientOption {
+	return func(c *Client) {
+		c.tlsConfig = cfg
+	}
+}
+
+// WithRequestObserver sets a function that is called after every HTTP request with the request,
+// the response (nil on failure), the error (if any) and the time the request took.
+func WithRequestObserver(f func(req *http.Request, resp *http.Response, err error, elapsed time.Duration)) ClientOption {
+	return func(c *Client) {
+		c.observer = f
+	}
+}
+
+// WithOffline makes the client return ErrOffline instead of making network requests.
+func WithOffline(offline bool) ClientOption {
+	return func(c *Client) {
+		c.offline = offline
+	}
+}
+
+// WithToken sets the token used to authenticate requests to the GitHub API, which raises the
+// GitHub rate limit for listing releases. By default the token is read from the GH_TOKEN or
+// GITHUB_TOKEN environment variable, see EnvToken. The token is only sent to the API URL, never to
+// the base URL or to download URLs.
+func WithToken(token string) ClientOption {
+	return WithTokenSource(StaticToken(token))
+}
+
+// WithTokenSource sets the source of the token used to authenticate requests to the GitHub API, see
+// WithToken. A nil source makes the requests unauthenticated.
+func WithTokenSource(ts TokenSource) ClientOption {
+	return func(c *Client) {
+		c.token = ts
+	}
+}
+
+// WithCacheMaxAge sets how long the release list returned by Releases is reused before it is
+// fetched again. The default is DefaultCacheMaxAge. Zero or a negative duration disables caching.
+func WithCacheMaxAge(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxAge = d
+	}
+}
+
+// WithMaxReleasePages limits the number of pages of up to 100 releases that are fetched when
+// listing releases. Zero or a negative number means no limit, which is the default. The GitHub API
+// lists releases newest first, so releases older than the last fetched page are left out of the
+// release list and of the release line channels.
+func WithMaxReleasePages(n int) ClientOption {
+	return func(c *Client) {
+		c.maxPages = n
+	}
+}
+
+// WithMinReleaseVersion makes the client leave releases older than v out of the release list, and
+// stop listing releases after a page that only contains older releases. Release lines older than v
+// have no channels and RecommendPatch finds no patches for them. Because each page must be read
+// before it is known whether the next one is needed, the pages are fetched one at a time.
+func WithMinReleaseVersion(v *Version) ClientOption {
+	return func(c *Client) {
+		c.minVersion = v
+	}
+}
+
+// NewClient returns a new Client configured with the supplied options.
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL:   DefaultBaseURL,
+		apiURL:    DefaultAPIURL,
+		timeout:   10 * time.Second,
+		token:     EnvToken(),
+		maxAge:    DefaultCacheMaxAge,
+		releases:  &releaseCache{},
+		rateLimit: &rateLimitState{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.httpClient == nil {
+		c.httpClient, c.err = newHTTPClient(c.timeout, c.tlsConfig)
+	}
+	return c
+}
+
+func newHTTPClient(timeout time.Duration, tlsConfig *tls.Config) (*http.Client, error) {
+	hc := &http.Client{Timeout: timeout}
+	if tlsConfig == nil {
+		return hc, nil
+	}
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("can't apply TLS client config: http.DefaultTransport is a %T, not an *http.Transport", http.DefaultTransport)
+	}
+	transport = transport.Clone()
+	transport.TLSClientConfig = tlsConfig.Clone()
+	hc.Transport = transport
+	return hc, nil
+}
+
+// defaultHTTP is the http.Client shared by the clients returned from DefaultClient. It is rebuilt
+// only when the package level Timeout or TLSClientConfig change, so connections are reused between
+// calls to the package level functions.
+var defaultHTTP struct {
+	sync.Mutex
+	built     bool
+	timeout   time.Duration
+	tlsConfig *tls.Config
+	client    *http.Client
+	err       error
+}
+
+func defaultHTTPClient() (*http.Client, error) {
+	defaultHTTP.Lock()
+	defer defaultHTTP.Unlock()
+	if !defaultHTTP.built || defaultHTTP.timeout != Timeout || defaultHTTP.tlsConfig != TLSClientConfig {
+		defaultHTTP.client, defaultHTTP.err = newHTTPClient(Timeout, TLSClientConfig)
+		defaultHTTP.timeout = Timeout
+		defaultHTTP.tlsConfig = TLSClientConfig
+		defaultHTTP.built = true
+	}
+	return defaultHTTP.client, defaultHTTP.err
+}
+
+// DefaultClient returns a client configured from the package level Timeout, TLSClientConfig,
+// RequestObserver and offline mode settings. The underlying http.Client is shared between the
+// returned clients as long as Timeout and TLSClientConfig are not changed, and so is the release
+// list cache.
+func DefaultClient() *Client {
+	hc, err := defaultHTTPClient()
+	c := NewClient(
+		WithHTTPClient(hc),
+		WithRequestObserver(RequestObserver),
+		WithOffline(IsOffline()),
+	)
+	if err != nil {
+		c.err = err
+	}
+	c.releases = defaultReleases
+	c.rateLimit = defaultRateLimit
+	return c
+}
+
+// LatestByPrerelease returns the latest released k0s version, if allowpre is true, prereleases are also accepted.
+func (c *Client) LatestByPrerelease(ctx context.Context, allowpre bool) (*Version, error) {
+	path := "stable.txt"
+	if allowpre {
+		path = "latest.txt"
+	}
+
+	v, err := c.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewVersion(v)
+}
+
+// LatestStable returns the latest non-prerelease k0s version.
+func (c *Client) LatestStable(ctx context.Context) (*Version, error) {
+	return c.LatestByPrerelease(ctx, false)
+}
+
+// Latest returns the latest k0s version, even if it is a prerelease.
+func (c *Client) Latest(ctx context.Context) (*Version, error) {
+	return c.LatestByPrerelease(ctx, true)
+}
+
+// Open makes a GET request to the given URL and returns the response body, which the caller must close.
+// Requests are made using the client's HTTP configuration and fail with ErrOffline in offline mode.
+func (c *Client) Open(ctx context.Context, u string) (io.ReadCloser, error) {
+	return c.open(ctx, u, false)
+}
+
+// open is like Open but when api is true, the request is made to the GitHub API and authenticated
+// with the client's token.
+func (c *Client) open(ctx context.Context, u string, api bool) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, u, api)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// do makes a GET request to u and returns the response if the status is 200. When api is true, the
+// request is made to the GitHub API with the token from the client's token source.
+func (c *Client) do(ctx context.Context, u string, api bool) (*http.Response, error) {
+	if c.offline {
+		return nil, ErrOffline
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("http request to %s failed: %w", u, err)
+	}
+	if api {
+		req.Header.Set("Accept", "application/vnd.github+json")
+		if c.token != nil {
+			token, err := c.token.Token(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("http request to %s failed: github token: %w", u, err)
+			}
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if api {
+		limit, ok := c.rateLimit.record(resp.Header)
+		rejected := resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests
+		if ok && rejected && limit.Remaining == 0 {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("http request to %s failed: %w until %s", u, ErrRateLimited, limit.Reset.Format(time.RFC3339))
+		}
+	}
+
+	if resp.StatusCode != 200 {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("http request to %s failed: backend returned %d", u, resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// send makes the request with the client's http.Client and reports it to the request observer.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if c.observer != nil {
+		c.observer(req, resp, err, time.Since(start))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("http request to %s failed: %w", req.URL, err)
+	}
+
+	if resp.Body == nil {
+		return nil, fmt.Errorf("http request to %s failed: nil body", req.URL)
+	}
+
+	return resp, nil
+}
+
+// CheckDownloadable verifies that the k0s binary for the version, os and arch exists by making a
+// HEAD request to its download URL. It returns an error wrapping ErrNotFound if it doesn't.
+func (c *Client) CheckDownloadable(ctx context.Context, v *Version, os, arch string) error {
+	if c.offline {
+		return ErrOffline
+	}
+	if c.err != nil {
+		return c.err
+	}
+
+	u := v.DownloadURL(os, arch)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u, nil)
+	if err != nil {
+		return fmt.Errorf("http request to %s failed: %w", u, err)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if c.observer != nil {
+		c.observer(req, resp, err, time.Since(start))
+	}
+	if err != nil {
+		return fmt.Errorf("http request to %s failed: %w", u, err)
+	}
+	if resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: %s", ErrNotFound, u)
+	case resp.StatusCode != 200:
+		return fmt.Errorf("http request to %s failed: backend returned %d", u, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Channels returns the available release channels and their current versions. The "stable" and
+// "latest" channels are read from stable.txt and latest.txt. Every release line in the release list
+// returned by Releases adds a "latest-<major>.<minor>" channel with its newest release and, if the
+// line has stable releases, a "stable-<major>.<minor>" channel with its newest stable release.
+func (c *Client) Channels(ctx context.Context) (map[string]*Version, error) {
+	stable, err := c.LatestStable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := c.Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := c.Releases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := map[string]*Version{"stable": stable, "latest": latest}
+	for mm, line := range releases.GroupByMajorMinor() {
+		if v := newestRelease(line, true); v != nil {
+			channels["latest-"+mm.String()] = v
+		}
+		if v := newestRelease(line, false); v != nil {
+			channels["stable-"+mm.String()] = v
+		}
+	}
+	return channels, nil
+}
+
+// ResolveChannel returns the current version of a release channel such as "stable", "latest" or
+// "stable-1.28". "stable" and "latest" are read from stable.txt and latest.txt, and a
+// per-release-line channel resolves to the newest release on that line from the release list,
+// excluding prereleases for "stable". An error wrapping ErrNotFound is returned when the channel is
+// unknown or has no releases.
+func (c *Client) ResolveChannel(ctx context.Context, name string) (*Version, error) {
+	match := channelNameRegex.FindStringSubmatch(name)
+	if match == nil || (match[1] != "stable" && match[1] != "latest") {
+		return nil, fmt.Errorf("%w: channel %s", ErrNotFound, name)
+	}
+	allowpre := match[1] == "latest"
+	if match[2] == "" {
+		return c.LatestByPrerelease(ctx, allowpre)
+	}
+	mm, err := ParseMajorMinor(match[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: channel %s", ErrNotFound, name)
+	}
+
+	releases, err := c.Releases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latest := newestRelease(releases.GroupByMajorMinor()[mm], allowpre)
+	if latest == nil {
+		return nil, fmt.Errorf("%w: channel %s has no releases", ErrNotFound, name)
+	}
+	return latest, nil
+}
+
+// RecommendPatch returns the newest stable release on the release line of the current version,
+// picked from the release list returned by Releases. If the current version is at least as new,
+// it is returned as is. An error wrapping ErrNotFound is returned when the release line has no
+// stable releases.
+func (c *Client) RecommendPatch(ctx context.Context, current *Version) (*Version, error) {
+	if current == nil {
+		return nil, fmt.Errorf("%w: nil version", ErrInvalidVersion)
+	}
+	releases, err := c.Releases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mm := current.MajorMinor()
+	candidate := newestRelease(releases.GroupByMajorMinor()[mm], false)
+	if candidate == nil {
+		return nil, fmt.Errorf("%w: no stable releases for %s", ErrNotFound, mm)
+	}
+
+	if candidate.GreaterThan(current) {
+		return candidate, nil
+	}
+	return current, nil
+}
+
+// newestRelease returns the newest version in the collection, or nil if there is none. Prereleases
+// are only considered when allowpre is true.
+func newestRelease(c Collection, allowpre bool) *Version {
+	var newest *Version
+	for _, v := range c {
+		if v.IsPrerelease() && !allowpre {
+			continue
+		}
+		if newest == nil || v.GreaterThan(newest) {
+			newest = v
+		}
+	}
+	return newest
+}
+
+func (c *Client) get(ctx context.Context, path string) (string, error) {
+	u := c.baseURL + "/" + path
+
+	body, err := c.Open(ctx, u)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", fmt.Errorf("http request to %s failed: %w when reading body", u, err)
+	}
+
+	if err := body.Close(); err != nil {
+		return "", fmt.Errorf("http request to %s failed: %w when closing body", u, err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// linkURL returns the URL of the link with the relation rel in the Link header of the response, as
+// used by the GitHub API and OCI registries for pagination, or an empty string if there is none.
+func linkURL(h http.Header, rel string) string {
+	for _, value := range h.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range parts[1:] {
+				if strings.TrimSpace(param) == `rel="`+rel+`"` {
+					return strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+				}
+			}
+		}
+	}
+	return ""
+}
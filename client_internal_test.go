@@ -0,0 +1,51 @@
+package version
+
+import (
+	"crypto/tls"
+	"testing"
+	"time"
+)
+
+func TestDefaultClientReusesHTTPClient(t *testing.T) {
+	a, b := DefaultClient(), DefaultClient()
+	if a.httpClient != b.httpClient {
+		t.Fatal("expected DefaultClient to reuse the http.Client")
+	}
+
+	origTimeout, origTLS := Timeout, TLSClientConfig
+	defer func() { Timeout, TLSClientConfig = origTimeout, origTLS }()
+
+	Timeout = time.Second
+	c := DefaultClient()
+	if c.httpClient == a.httpClient {
+		t.Fatal("expected a new http.Client after changing Timeout")
+	}
+	if c.httpClient.Timeout != time.Second {
+		t.Fatalf("expected timeout 1s, got %s", c.httpClient.Timeout)
+	}
+
+	TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	d, e := DefaultClient(), DefaultClient()
+	if d.httpClient == c.httpClient {
+		t.Fatal("expected a new http.Client after changing TLSClientConfig")
+	}
+	if d.httpClient != e.httpClient || d.httpClient.Transport != e.httpClient.Transport {
+		t.Fatal("expected DefaultClient to reuse the http.Client and its transport")
+	}
+}
+
+func TestParseRepository(t *testing.T) {
+	for ref, want := range map[string][2]string{
+		"docker.io/k0sproject/k0s":       {"registry-1.docker.io", "k0sproject/k0s"},
+		"k0sproject/k0s":                 {"registry-1.docker.io", "k0sproject/k0s"},
+		"docker.io/k0s":                  {"registry-1.docker.io", "library/k0s"},
+		"quay.io/k0sproject/k0s":         {"quay.io", "k0sproject/k0s"},
+		"localhost/k0s":                  {"localhost", "k0s"},
+		"registry.local:5000/mirror/k0s": {"registry.local:5000", "mirror/k0s"},
+	} {
+		host, name := parseRepository(ref)
+		if host != want[0] || name != want[1] {
+			t.Fatalf("parseRepository(%q) = %q, %q, expected %q, %q", ref, host, name, want[0], want[1])
+		}
+	}
+}
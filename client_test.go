@@ -0,0 +1,539 @@
+package version_test
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/version"
+)
+
+type testRelease struct {
+	TagName     string    `json:"tag_name"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// testReleases returns the releases served by newTestServer, newest first like the GitHub API.
+// There are more than fit on one page, so listing them needs pagination.
+func testReleases() []testRelease {
+	releases := []testRelease{
+		{TagName: "v1.30.0+k0s.0", Draft: true},
+		{TagName: "v1.30.0-rc.1+k0s.0"},
+		{TagName: "v1.29.2+k0s.0"},
+		{TagName: "v1.29.1+k0s.0"},
+		{TagName: "v1.29.0+k0s.0"},
+		{TagName: "v1.29.0-rc.1+k0s.0"},
+		{TagName: "docs-preview"},
+		{TagName: "v1.28.7-rc.1+k0s.0"},
+	}
+	for patch := 6; patch >= 0; patch-- {
+		releases = append(releases, testRelease{TagName: fmt.Sprintf("v1.28.%d+k0s.0", patch)})
+	}
+	for minor := 27; minor >= 20; minor-- {
+		for patch := 14; patch >= 0; patch-- {
+			releases = append(releases, testRelease{TagName: fmt.Sprintf("v1.%d.%d+k0s.0", minor, patch)})
+		}
+	}
+	// One release a day, the newest published on 2024-03-01.
+	for i := range releases {
+		releases[i].Prerelease = strings.Contains(releases[i].TagName, "-rc.")
+		releases[i].PublishedAt = time.Date(2024, 3, 1-i, 12, 0, 0, 0, time.UTC)
+	}
+	return releases
+}
+
+// newTestServer returns a TLS server that serves stable.txt, latest.txt and a paginated GitHub style
+// /releases listing.
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewTLSServer(newTestMux(true))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestMux returns the handler of newTestServer. When links is false, the /releases responses
+// have no Link header.
+func newTestMux(links bool) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/releases", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		if page < 1 || perPage < 1 {
+			http.Error(w, "bad pagination", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(5000-page))
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		releases := testReleases()
+		if last := (len(releases) + perPage - 1) / perPage; links && page < last {
+			u := *r.URL
+			u.Scheme, u.Host = "https", r.Host
+			q := u.Query()
+			q.Set("page", strconv.Itoa(page+1))
+			u.RawQuery = q.Encode()
+			next := u.String()
+			q.Set("page", strconv.Itoa(last))
+			u.RawQuery = q.Encode()
+			w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next", <%s>; rel="last"`, next, u.String()))
+		}
+		start, end := (page-1)*perPage, page*perPage
+		if start > len(releases) {
+			start = len(releases)
+		}
+		if end > len(releases) {
+			end = len(releases)
+		}
+		_ = json.NewEncoder(w).Encode(releases[start:end])
+	})
+	mux.HandleFunc("/stable.txt", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "v1.29.2+k0s.0")
+	})
+	mux.HandleFunc("/latest.txt", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "v1.30.0-rc.1+k0s.0")
+	})
+	return mux
+}
+
+// newTestClient returns a client that makes all of its requests to the test server.
+func newTestClient(server *httptest.Server, opts ...version.ClientOption) *version.Client {
+	opts = append([]version.ClientOption{
+		version.WithBaseURL(server.URL),
+		version.WithAPIURL(server.URL),
+		version.WithHTTPClient(server.Client()),
+	}, opts...)
+	return version.NewClient(opts...)
+}
+
+func TestClientChannels(t *testing.T) {
+	c := newTestClient(newTestServer(t))
+	channels, err := c.Channels(context.Background())
+	NoError(t, err)
+	Equal(t, "v1.29.2+k0s.0", channels["stable"].String())
+	Equal(t, "v1.30.0-rc.1+k0s.0", channels["latest"].String())
+	Equal(t, "v1.29.2+k0s.0", channels["stable-1.29"].String())
+	Equal(t, "v1.28.6+k0s.0", channels["stable-1.28"].String())
+	Equal(t, "v1.28.7-rc.1+k0s.0", channels["latest-1.28"].String())
+	Equal(t, "v1.30.0-rc.1+k0s.0", channels["latest-1.30"].String())
+	Equal(t, "v1.20.14+k0s.0", channels["stable-1.20"].String())
+	_, ok := channels["stable-1.30"]
+	False(t, ok)
+	// stable and latest plus stable and latest for 1.20 to 1.29 and latest for 1.30
+	Equal(t, 2+2*10+1, len(channels))
+}
+
+func TestClientResolveChannel(t *testing.T) {
+	c := newTestClient(newTestServer(t))
+
+	testCases := []struct {
+		name string
+		want string
+	}{
+		{"stable", "v1.29.2+k0s.0"},
+		{"latest", "v1.30.0-rc.1+k0s.0"},
+		{"stable-1.29", "v1.29.2+k0s.0"},
+		{"stable-1.28", "v1.28.6+k0s.0"},
+		{"latest-1.28", "v1.28.7-rc.1+k0s.0"},
+		{"stable-1.21", "v1.21.14+k0s.0"},
+		{"stable-1.20", "v1.20.14+k0s.0"},
+		{"latest-1.30", "v1.30.0-rc.1+k0s.0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := c.ResolveChannel(context.Background(), tc.name)
+			NoError(t, err)
+			Equal(t, tc.want, v.String())
+		})
+	}
+
+	for _, name := range []string{"stable-1.30", "stable-1.19", "stable-1", "nightly", "nightly-1.29"} {
+		_, err := c.ResolveChannel(context.Background(), name)
+		True(t, errors.Is(err, version.ErrNotFound))
+	}
+
+	t.Run("offline", func(t *testing.T) {
+		c := newTestClient(newTestServer(t), version.WithOffline(true))
+		_, err := c.ResolveChannel(context.Background(), "stable-1.28")
+		True(t, errors.Is(err, version.ErrOffline))
+	})
+}
+
+func TestClientReleases(t *testing.T) {
+	c := newTestClient(newTestServer(t))
+	releases, err := c.Releases(context.Background())
+	NoError(t, err)
+	Equal(t, len(testReleases())-2, len(releases))
+	Equal(t, "v1.30.0-rc.1+k0s.0", releases[0].String())
+	Equal(t, "v1.20.0+k0s.0", releases[len(releases)-1].String())
+}
+
+// pagedReleases returns a handler that serves a release list of the given number of full pages
+// with a Link header pointing to the last page. The versions decrease from v1.<pages-1>.99 on the
+// first page to v1.0.0 on the last one, and later pages are served faster so that concurrent
+// requests complete out of order.
+func pagedReleases(pages int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		time.Sleep(time.Duration(pages-page) * time.Millisecond)
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/releases?per_page=100&page=%d>; rel="last"`, r.Host, pages))
+		releases := make([]testRelease, 100)
+		for i := range releases {
+			n := (pages-page)*100 + 99 - i
+			releases[i] = testRelease{TagName: fmt.Sprintf("v1.%d.%d+k0s.0", n/100, n%100)}
+		}
+		_ = json.NewEncoder(w).Encode(releases)
+	})
+}
+
+func TestClientReleasesConcurrentPages(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	handler := pagedReleases(10)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	c := newTestClient(server)
+	releases, err := c.Releases(context.Background())
+	NoError(t, err)
+	Equal(t, 1000, len(releases))
+	for i := 1; i < len(releases); i++ {
+		True(t, releases[i].LessThan(releases[i-1]))
+	}
+	True(t, maxInFlight <= 4)
+}
+
+func TestClientReleaseLimits(t *testing.T) {
+	server := httptest.NewTLSServer(pagedReleases(10))
+	t.Cleanup(server.Close)
+	var requests int32
+	observer := version.WithRequestObserver(func(_ *http.Request, _ *http.Response, _ error, _ time.Duration) {
+		atomic.AddInt32(&requests, 1)
+	})
+
+	t.Run("max pages", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		c := newTestClient(server, observer, version.WithMaxReleasePages(3))
+		releases, err := c.Releases(context.Background())
+		NoError(t, err)
+		Equal(t, int32(3), atomic.LoadInt32(&requests))
+		Equal(t, 300, len(releases))
+		Equal(t, "v1.7.0+k0s.0", releases[len(releases)-1].String())
+	})
+
+	t.Run("min version", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		c := newTestClient(server, observer, version.WithMinReleaseVersion(version.MustParse("v1.7.50")))
+		releases, err := c.Releases(context.Background())
+		NoError(t, err)
+		// The fourth page, which has no release at or above v1.7.50, ends the listing.
+		Equal(t, int32(4), atomic.LoadInt32(&requests))
+		Equal(t, 250, len(releases))
+		Equal(t, "v1.7.50+k0s.0", releases[len(releases)-1].String())
+	})
+}
+
+func TestClientReleasesWithoutLinks(t *testing.T) {
+	server := httptest.NewTLSServer(newTestMux(false))
+	t.Cleanup(server.Close)
+	var pages []string
+	c := newTestClient(server, version.WithRequestObserver(func(req *http.Request, _ *http.Response, _ error, _ time.Duration) {
+		pages = append(pages, req.URL.Query().Get("page"))
+	}))
+	releases, err := c.Releases(context.Background())
+	NoError(t, err)
+	Equal(t, []string{"1", "2"}, pages)
+	Equal(t, len(testReleases())-2, len(releases))
+	Equal(t, "v1.20.0+k0s.0", releases[len(releases)-1].String())
+}
+
+func TestClientReleaseList(t *testing.T) {
+	c := newTestClient(newTestServer(t))
+	list, err := c.ReleaseList(context.Background())
+	NoError(t, err)
+	Equal(t, len(testReleases())-2, len(list))
+	Equal(t, "v1.30.0-rc.1+k0s.0", list[0].Version.String())
+	True(t, list[0].Prerelease)
+	Equal(t, "2024-02-29", list[0].PublishedAt.Format("2006-01-02"))
+	Equal(t, "v1.29.2+k0s.0", list[1].Version.String())
+	False(t, list[1].Prerelease)
+	Equal(t, "2024-02-28", list[1].PublishedAt.Format("2006-01-02"))
+}
+
+func TestClientEachRelease(t *testing.T) {
+	server := httptest.NewTLSServer(pagedReleases(10))
+	t.Cleanup(server.Close)
+	var requests int32
+	c := newTestClient(server, version.WithRequestObserver(func(_ *http.Request, _ *http.Response, _ error, _ time.Duration) {
+		atomic.AddInt32(&requests, 1)
+	}))
+
+	errStop := errors.New("stop")
+	var seen []string
+	err := c.EachRelease(context.Background(), func(r version.Release) error {
+		seen = append(seen, r.Version.String())
+		if len(seen) == 150 {
+			return errStop
+		}
+		return nil
+	})
+	True(t, errors.Is(err, errStop))
+	Equal(t, 150, len(seen))
+	Equal(t, "v1.9.99+k0s.0", seen[0])
+	Equal(t, "v1.8.50+k0s.0", seen[149])
+
+	seen = seen[:0]
+	err = c.EachRelease(context.Background(), func(r version.Release) error {
+		seen = append(seen, r.Version.String())
+		return nil
+	})
+	NoError(t, err)
+	Equal(t, 1000, len(seen))
+	Equal(t, "v1.0.0+k0s.0", seen[999])
+
+	// The complete walk filled the cache.
+	atomic.StoreInt32(&requests, 0)
+	releases, err := c.Releases(context.Background())
+	NoError(t, err)
+	Equal(t, 1000, len(releases))
+	Equal(t, int32(0), atomic.LoadInt32(&requests))
+}
+
+func TestClientReleasesCache(t *testing.T) {
+	server := newTestServer(t)
+	var requests int
+	observer := version.WithRequestObserver(func(req *http.Request, _ *http.Response, _ error, _ time.Duration) {
+		if req.URL.Path == "/releases" {
+			requests++
+		}
+	})
+
+	c := newTestClient(server, observer)
+	releases, err := c.Releases(context.Background())
+	NoError(t, err)
+	Equal(t, 2, requests)
+	releases[0] = nil
+
+	_, err = c.ResolveChannel(context.Background(), "stable-1.28")
+	NoError(t, err)
+	_, err = c.RecommendPatch(context.Background(), version.MustParse("v1.27.1+k0s.0"))
+	NoError(t, err)
+	releases, err = c.Releases(context.Background())
+	NoError(t, err)
+	Equal(t, 2, requests)
+	Equal(t, "v1.30.0-rc.1+k0s.0", releases[0].String())
+
+	requests = 0
+	c = newTestClient(server, observer, version.WithCacheMaxAge(0))
+	for i := 0; i < 2; i++ {
+		_, err = c.Releases(context.Background())
+		NoError(t, err)
+	}
+	Equal(t, 4, requests)
+}
+
+func TestClientToken(t *testing.T) {
+	server := newTestServer(t)
+	t.Setenv("GITHUB_TOKEN", "github-token")
+	t.Setenv("GH_TOKEN", "gh-token")
+
+	auth := map[string]string{}
+	observer := version.WithRequestObserver(func(req *http.Request, _ *http.Response, _ error, _ time.Duration) {
+		auth[req.URL.Path] = req.Header.Get("Authorization")
+	})
+
+	c := newTestClient(server, observer)
+	_, err := c.ResolveChannel(context.Background(), "stable")
+	NoError(t, err)
+	_, err = c.Releases(context.Background())
+	NoError(t, err)
+	Equal(t, "Bearer gh-token", auth["/releases"])
+	Equal(t, "", auth["/stable.txt"])
+
+	t.Setenv("GH_TOKEN", "")
+	c = newTestClient(server, observer)
+	_, err = c.Releases(context.Background())
+	NoError(t, err)
+	Equal(t, "Bearer github-token", auth["/releases"])
+
+	c = newTestClient(server, observer, version.WithToken("option-token"))
+	_, err = c.Releases(context.Background())
+	NoError(t, err)
+	Equal(t, "Bearer option-token", auth["/releases"])
+
+	c = newTestClient(server, observer, version.WithToken(""))
+	_, err = c.Releases(context.Background())
+	NoError(t, err)
+	Equal(t, "", auth["/releases"])
+}
+
+func TestClientRecommendPatch(t *testing.T) {
+	c := newTestClient(newTestServer(t))
+
+	testCases := []struct {
+		current string
+		want    string
+	}{
+		{"v1.28.2+k0s.0", "v1.28.6+k0s.0"},
+		{"v1.28.6+k0s.0", "v1.28.6+k0s.0"},
+		{"v1.28.7+k0s.0", "v1.28.7+k0s.0"},
+		{"v1.28.6-rc.1+k0s.0", "v1.28.6+k0s.0"},
+		{"v1.29.0+k0s.1", "v1.29.2+k0s.0"},
+		{"v1.21.3+k0s.0", "v1.21.14+k0s.0"},
+		{"v1.20.0", "v1.20.14+k0s.0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.current, func(t *testing.T) {
+			v, err := c.RecommendPatch(context.Background(), version.MustParse(tc.current))
+			NoError(t, err)
+			Equal(t, tc.want, v.String())
+		})
+	}
+
+	for _, current := range []string{"v1.19.1+k0s.0", "v1.30.0-rc.1+k0s.0"} {
+		_, err := c.RecommendPatch(context.Background(), version.MustParse(current))
+		True(t, errors.Is(err, version.ErrNotFound))
+	}
+
+	_, err := c.RecommendPatch(context.Background(), nil)
+	True(t, errors.Is(err, version.ErrInvalidVersion))
+}
+
+func TestClient(t *testing.T) {
+	server := newTestServer(t)
+	c := version.NewClient(version.WithBaseURL(server.URL+"/"), version.WithHTTPClient(server.Client()))
+
+	t.Run("LatestStable", func(t *testing.T) {
+		v, err := c.LatestStable(context.Background())
+		NoError(t, err)
+		Equal(t, "v1.29.2+k0s.0", v.String())
+	})
+
+	t.Run("Latest", func(t *testing.T) {
+		v, err := c.Latest(context.Background())
+		NoError(t, err)
+		Equal(t, "v1.30.0-rc.1+k0s.0", v.String())
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		c := version.NewClient(version.WithBaseURL(server.URL))
+		_, err := c.Latest(context.Background())
+		Error(t, err)
+	})
+
+	t.Run("TLS config", func(t *testing.T) {
+		transport := server.Client().Transport.(*http.Transport)
+		c := version.NewClient(version.WithBaseURL(server.URL), version.WithTLSClientConfig(transport.TLSClientConfig))
+		v, err := c.Latest(context.Background())
+		NoError(t, err)
+		Equal(t, "v1.30.0-rc.1+k0s.0", v.String())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		c := version.NewClient(version.WithBaseURL(server.URL+"/missing"), version.WithHTTPClient(server.Client()))
+		_, err := c.Latest(context.Background())
+		Error(t, err)
+	})
+}
+
+func TestClientRequestObserver(t *testing.T) {
+	server := newTestServer(t)
+	var status int
+	c := version.NewClient(
+		version.WithBaseURL(server.URL),
+		version.WithHTTPClient(server.Client()),
+		version.WithRequestObserver(func(req *http.Request, resp *http.Response, err error, _ time.Duration) {
+			NoError(t, err)
+			Equal(t, "/stable.txt", req.URL.Path)
+			status = resp.StatusCode
+		}),
+	)
+	_, err := c.LatestStable(context.Background())
+	NoError(t, err)
+	Equal(t, http.StatusOK, status)
+}
+
+func TestClientOffline(t *testing.T) {
+	server := newTestServer(t)
+	c := version.NewClient(version.WithBaseURL(server.URL), version.WithHTTPClient(server.Client()), version.WithOffline(true))
+	_, err := c.Latest(context.Background())
+	True(t, errors.Is(err, version.ErrOffline))
+}
+
+func TestClientOpen(t *testing.T) {
+	server := newTestServer(t)
+	c := version.NewClient(version.WithHTTPClient(server.Client()))
+
+	body, err := c.Open(context.Background(), server.URL+"/stable.txt")
+	NoError(t, err)
+	data, err := io.ReadAll(body)
+	NoError(t, err)
+	NoError(t, body.Close())
+	Equal(t, "v1.29.2+k0s.0\n", string(data))
+
+	_, err = c.Open(context.Background(), server.URL+"/missing.txt")
+	Error(t, err)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClientCheckDownloadable(t *testing.T) {
+	c := version.NewClient(version.WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			Equal(t, http.MethodHead, req.Method)
+			status := http.StatusNotFound
+			if strings.HasSuffix(req.URL.Path, "-amd64") {
+				status = http.StatusOK
+			}
+			return &http.Response{StatusCode: status, Body: http.NoBody, Request: req}, nil
+		}),
+	}))
+
+	v := version.MustParse("v1.29.2+k0s.0")
+	NoError(t, c.CheckDownloadable(context.Background(), v, "linux", "amd64"))
+
+	err := c.CheckDownloadable(context.Background(), v, "linux", "riscv64")
+	True(t, errors.Is(err, version.ErrNotFound))
+}
+
+func TestClientTLSClientConfigUnsupportedTransport(t *testing.T) {
+	orig := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("unexpected request")
+	})
+	defer func() { http.DefaultTransport = orig }()
+
+	c := version.NewClient(version.WithTLSClientConfig(&tls.Config{MinVersion: tls.VersionTLS12}))
+	_, err := c.LatestStable(context.Background())
+	Error(t, err)
+	True(t, strings.Contains(err.Error(), "TLS client config"))
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/k0sproject/version"
+)
+
+const (
+	exitUnsatisfied = 1
+	exitInvalid     = 2
+)
+
+// check prints the versions that do not satisfy the constraint and returns an exit code:
+// 0 if all versions satisfy it, exitUnsatisfied if some don't and exitInvalid on invalid input.
+func check(args []string) int {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	quiet := fs.Bool("q", false, "print nothing, only set the exit code")
+	verbose := fs.Bool("v", false, "print the result for every version")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: k0s_sort check [-q|-v] <constraint> <version ...>")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return exitInvalid
+	}
+
+	c, err := version.NewConstraint(fs.Arg(0))
+	if err != nil {
+		println(err.Error())
+		return exitInvalid
+	}
+
+	versions := make([]*version.Version, 0, fs.NArg()-1)
+	for _, arg := range fs.Args()[1:] {
+		v, err := version.NewVersion(arg)
+		if err != nil {
+			println(err.Error())
+			return exitInvalid
+		}
+		versions = append(versions, v)
+	}
+
+	code := 0
+	for _, v := range versions {
+		if c.Check(v) {
+			if *verbose && !*quiet {
+				fmt.Printf("%s satisfies %s\n", versionString(v), c)
+			}
+			continue
+		}
+		if !*quiet {
+			fmt.Printf("%s does not satisfy %s\n", versionString(v), c)
+		}
+		code = exitUnsatisfied
+	}
+
+	return code
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/k0sproject/version"
+)
+
+// compare prints -1, 0 or 1 depending on whether the first version is lower than, equal to or
+// greater than the second and returns an exit code.
+func compare(args []string) int {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	human := fs.Bool("human", false, "print the result as a phrase instead of a number")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: k0s_sort compare [-human] <version> <version>")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitInvalid
+	}
+
+	a, err := version.NewVersion(fs.Arg(0))
+	if err != nil {
+		println(err.Error())
+		return exitInvalid
+	}
+	b, err := version.NewVersion(fs.Arg(1))
+	if err != nil {
+		println(err.Error())
+		return exitInvalid
+	}
+
+	result := a.Compare(b)
+	if !*human {
+		fmt.Println(result)
+		return 0
+	}
+
+	switch result {
+	case -1:
+		fmt.Printf("%s is lower than %s\n", versionString(a), versionString(b))
+	case 1:
+		fmt.Printf("%s is greater than %s\n", versionString(a), versionString(b))
+	default:
+		fmt.Printf("%s is equal to %s\n", versionString(a), versionString(b))
+	}
+
+	return 0
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+const bashCompletion = `_k0s_sort() {
+	local cur="${COMP_WORDS[COMP_CWORD]}"
+	case "${COMP_WORDS[1]}" in
+	latest)
+		COMPREPLY=($(compgen -W "-stable -satisfying" -- "$cur"))
+		return
+		;;
+	releases)
+		COMPREPLY=($(compgen -W "-stable -satisfying -wide -json" -- "$cur"))
+		return
+		;;
+	check)
+		COMPREPLY=($(compgen -W "-q -v" -- "$cur"))
+		return
+		;;
+	compare)
+		COMPREPLY=($(compgen -W "-human" -- "$cur"))
+		return
+		;;
+	fmt)
+		COMPREPLY=($(compgen -W "-w -prefix" -- "$cur") $(compgen -f -- "$cur"))
+		return
+		;;
+	completion)
+		COMPREPLY=($(compgen -W "bash zsh fish" -- "$cur"))
+		return
+		;;
+	esac
+	if [[ "$cur" == -* ]]; then
+		COMPREPLY=($(compgen -W "-0 -debug -exec -format -interval -l -no-v-prefix -o -output -r -s -satisfying -unique -v -watch" -- "$cur"))
+	elif [[ $COMP_CWORD -eq 1 ]]; then
+		COMPREPLY=($(compgen -W "check latest releases compare lint fmt completion" -- "$cur") $(compgen -f -- "$cur"))
+	else
+		COMPREPLY=($(compgen -f -- "$cur"))
+	fi
+}
+complete -F _k0s_sort k0s_sort
+`
+
+const zshCompletion = `#compdef k0s_sort
+
+_k0s_sort() {
+	case "${words[2]}" in
+	latest)
+		_arguments '-stable[only consider stable versions]' '-satisfying[constraint to satisfy]:constraint:'
+		return
+		;;
+	releases)
+		_arguments '-stable[omit prereleases]' '-satisfying[constraint to satisfy]:constraint:' '-wide[print release dates]' '-json[print as JSON]'
+		return
+		;;
+	check)
+		_arguments '-q[print nothing, only set the exit code]' '-v[print the result for every version]'
+		return
+		;;
+	compare)
+		_arguments '-human[print the result as a phrase]'
+		return
+		;;
+	fmt)
+		_arguments '-w[write the result to the file]' '-prefix[prefix to write before each version]:prefix:' '*:file:_files'
+		return
+		;;
+	completion)
+		_values 'shell' bash zsh fish
+		return
+		;;
+	esac
+	_arguments \
+		'-0[read and write NUL separated records]' \
+		'-debug[print HTTP requests to stderr]' \
+		'-exec[command to execute for each new version in -watch mode]:command:_command_names' \
+		'-format[format output using a go template]:template:' \
+		'-interval[polling interval for -watch]:duration:' \
+		'-l[only print the latest version from input]' \
+		'-no-v-prefix[print versions without the v prefix]' \
+		'-o[print the latest version from online]' \
+		'-output[print versions as csv or tsv]:format:(csv tsv)' \
+		'-r[sort in descending order]' \
+		'-s[omit prerelease versions]' \
+		'-satisfying[only print versions that satisfy the constraint]:constraint:' \
+		'-unique[omit duplicate versions]' \
+		'-v[print k0s_sort version]' \
+		'-watch[poll online for new releases]' \
+		'1:command or file:(check latest releases compare lint fmt completion)' \
+		'*:file:_files'
+}
+
+compdef _k0s_sort k0s_sort
+`
+
+const fishCompletion = `set -l commands check latest releases compare lint fmt completion
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -a "$commands"
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o 0 -d 'read and write NUL separated records'
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o debug -d 'print HTTP requests to stderr'
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o format -r -d 'format output using a go template'
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o l -d 'only print the latest version from input'
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o o -d 'print the latest version from online'
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o output -x -a "csv tsv" -d 'print versions as csv or tsv'
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o no-v-prefix -d 'print versions without the v prefix'
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o r -d 'sort in descending order'
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o s -d 'omit prerelease versions'
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o satisfying -r -d 'only print versions that satisfy the constraint'
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o unique -d 'omit duplicate versions'
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o watch -d 'poll online for new releases'
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o interval -r -d 'polling interval for -watch'
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o exec -r -d 'command to execute for each new version in -watch mode'
+complete -c k0s_sort -n "not __fish_seen_subcommand_from $commands" -o v -d 'print k0s_sort version'
+complete -c k0s_sort -n "__fish_seen_subcommand_from latest" -o stable -d 'only consider stable versions'
+complete -c k0s_sort -n "__fish_seen_subcommand_from latest" -o satisfying -r -d 'constraint to satisfy'
+complete -c k0s_sort -n "__fish_seen_subcommand_from releases" -o stable -d 'omit prereleases'
+complete -c k0s_sort -n "__fish_seen_subcommand_from releases" -o satisfying -r -d 'constraint to satisfy'
+complete -c k0s_sort -n "__fish_seen_subcommand_from releases" -o wide -d 'print release dates'
+complete -c k0s_sort -n "__fish_seen_subcommand_from releases" -o json -d 'print as JSON'
+complete -c k0s_sort -n "__fish_seen_subcommand_from check" -o q -d 'print nothing, only set the exit code'
+complete -c k0s_sort -n "__fish_seen_subcommand_from check" -o v -d 'print the result for every version'
+complete -c k0s_sort -n "__fish_seen_subcommand_from compare" -o human -d 'print the result as a phrase'
+complete -c k0s_sort -n "__fish_seen_subcommand_from fmt" -o w -d 'write the result to the file'
+complete -c k0s_sort -n "__fish_seen_subcommand_from fmt" -o prefix -r -d 'prefix to write before each version'
+complete -c k0s_sort -n "__fish_seen_subcommand_from completion" -f -a "bash zsh fish"
+`
+
+// completion prints a shell completion script and returns an exit code.
+func completion(args []string) int {
+	fs := flag.NewFlagSet("completion", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: k0s_sort completion bash|zsh|fish")
+	}
+	_ = fs.Parse(args)
+
+	switch fs.Arg(0) {
+	case "bash":
+		fmt.Print(bashCompletion)
+	case "zsh":
+		fmt.Print(zshCompletion)
+	case "fish":
+		fmt.Print(fishCompletion)
+	default:
+		fs.Usage()
+		return exitInvalid
+	}
+
+	return 0
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/k0sproject/version"
+)
+
+// listEntry is a version in a version list file with the comment lines directly above it.
+type listEntry struct {
+	comments []string
+	v        *version.Version
+}
+
+// formatList returns the version list sorted, deduplicated and with every version written with
+// the given prefix. Comment lines stay attached to the version that follows them, comments of
+// removed duplicates move to the version that is kept, and comments after the last version stay
+// at the end. A comment block at the start of the file followed by an empty line is kept as a
+// header above all versions. Empty lines are dropped. Invalid entries are reported with their line number as
+// found by version.LintList.
+func formatList(data []byte, prefix string) ([]byte, error) {
+	for _, d := range version.LintList(bytes.NewReader(data)) {
+		if d.Err != nil {
+			return nil, fmt.Errorf("line %d: %w", d.Line, d.Err)
+		}
+	}
+
+	var (
+		header  []string
+		entries []listEntry
+		pending []string
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			if len(entries) == 0 && header == nil {
+				header, pending = pending, nil
+			}
+			continue
+		case strings.HasPrefix(line, "#"):
+			pending = append(pending, line)
+			continue
+		}
+		v, err := version.NewVersion(line)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, listEntry{comments: pending, v: v})
+		pending = nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].v, entries[j].v
+		if cmp := a.Compare(b); cmp != 0 {
+			return cmp < 0
+		}
+		return a.String() < b.String()
+	})
+
+	var buf bytes.Buffer
+	for _, c := range header {
+		buf.WriteString(c + "\n")
+	}
+	if len(header) > 0 {
+		buf.WriteString("\n")
+	}
+	for i, e := range entries {
+		if i+1 < len(entries) && entries[i+1].v.Equal(e.v) {
+			// keep the last of the duplicates so that all of their comments can be written above it
+			entries[i+1].comments = append(e.comments, entries[i+1].comments...)
+			continue
+		}
+		for _, c := range e.comments {
+			buf.WriteString(c + "\n")
+		}
+		buf.WriteString(prefix + strings.TrimPrefix(e.v.String(), "v") + "\n")
+	}
+	for _, c := range pending {
+		buf.WriteString(c + "\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// formatFiles formats version list files and returns an exit code. Without -w the result is
+// printed to stdout, with -w the files are rewritten in place.
+func formatFiles(args []string) int {
+	fs := flag.NewFlagSet("fmt", flag.ExitOnError)
+	write := fs.Bool("w", false, "write the result to the file instead of stdout")
+	prefix := fs.String("prefix", "v", "prefix to write before each version, use an empty string for none")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: k0s_sort fmt [-w] [-prefix <prefix>] [filename ...]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		if *write {
+			println("can't use -w when reading stdin")
+			return exitInvalid
+		}
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			println("can't read stdin:", err.Error())
+			return exitInvalid
+		}
+		out, err := formatList(data, *prefix)
+		if err != nil {
+			println("<stdin>:", err.Error())
+			return exitInvalid
+		}
+		if _, err := os.Stdout.Write(out); err != nil {
+			println("failed to write output:", err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	for _, name := range fs.Args() {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			println("can't open file:", err.Error())
+			return exitInvalid
+		}
+		out, err := formatList(data, *prefix)
+		if err != nil {
+			println(name+":", err.Error())
+			return exitInvalid
+		}
+		if !*write {
+			if _, err := os.Stdout.Write(out); err != nil {
+				println("failed to write output:", err.Error())
+				return 1
+			}
+			continue
+		}
+		if bytes.Equal(data, out) {
+			continue
+		}
+		stat, err := os.Stat(name)
+		if err != nil {
+			println("can't stat file:", err.Error())
+			return exitInvalid
+		}
+		if err := os.WriteFile(name, out, stat.Mode().Perm()); err != nil {
+			println("can't write file:", err.Error())
+			return exitInvalid
+		}
+	}
+
+	return 0
+}
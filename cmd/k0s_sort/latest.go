@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/k0sproject/version"
+)
+
+// latest prints the latest version resolved online and returns an exit code.
+func latest(args []string) int {
+	fs := flag.NewFlagSet("latest", flag.ExitOnError)
+	stable := fs.Bool("stable", false, "only consider stable versions")
+	satisfying := fs.String("satisfying", "", "print the latest released version that satisfies the constraint")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: k0s_sort latest [options]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if *satisfying == "" {
+		v, err := client.LatestByPrerelease(context.Background(), !*stable)
+		if err != nil {
+			println("failed to get latest version:", err.Error())
+			return 1
+		}
+		printVersion(v)
+		return 0
+	}
+
+	c, err := version.NewConstraint(*satisfying)
+	if err != nil {
+		println(err.Error())
+		return exitInvalid
+	}
+
+	releases, err := client.Releases(context.Background())
+	if err != nil {
+		println("failed to list releases:", err.Error())
+		return 1
+	}
+	if *stable {
+		stableReleases := releases[:0]
+		for _, v := range releases {
+			if !v.IsPrerelease() {
+				stableReleases = append(stableReleases, v)
+			}
+		}
+		releases = stableReleases
+	}
+
+	v, err := version.SelectLatest(releases, c)
+	if errors.Is(err, version.ErrNoVersions) {
+		println("no version satisfying", c.String(), "found")
+		return exitUnsatisfied
+	}
+	if err != nil {
+		println(err.Error())
+		return 1
+	}
+	printVersion(v)
+	return 0
+}
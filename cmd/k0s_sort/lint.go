@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/k0sproject/version"
+)
+
+// lint prints the problems found in version list files and returns an exit code: 0 if the lists
+// are clean, exitUnsatisfied if problems were found and exitInvalid if a file can't be read.
+func lint(args []string) int {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: k0s_sort lint [filename|url ...]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	names := fs.Args()
+	if len(names) == 0 {
+		names = []string{"-"}
+	}
+
+	code := 0
+	for _, name := range names {
+		var r io.ReadCloser
+		if name == "-" {
+			r = os.Stdin
+		} else {
+			f, err := open(name)
+			if err != nil {
+				println("can't open file:", err.Error())
+				return exitInvalid
+			}
+			r = f
+		}
+		diags := version.LintList(r)
+		r.Close()
+
+		label := name
+		if name == "-" {
+			label = "<stdin>"
+		}
+		for _, d := range diags {
+			fmt.Printf("%s:%d: %s\n", label, d.Line, d.Message)
+			code = exitUnsatisfied
+		}
+	}
+
+	return code
+}
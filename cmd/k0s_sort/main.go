@@ -2,13 +2,19 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
 	"flag"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
-	"sort"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/k0sproject/version"
 	toolversion "github.com/k0sproject/version/internal/version"
@@ -19,29 +25,180 @@ var (
 	latestFlag     bool
 	onlineFlag     bool
 	stableOnlyFlag bool
+	formatFlag     string
+	reverseFlag    bool
+	uniqueFlag     bool
+	watchFlag      bool
+	intervalFlag   time.Duration
+	execFlag       string
+	satisfyingFlag string
+	noVPrefixFlag  bool
+	debugFlag      bool
+	nulFlag        bool
+	outputFlag     string
+
+	client         *version.Client
+	outputTemplate *template.Template
+	outputCSV      *csv.Writer
+	csvHeaderDone  bool
 )
 
+func debugRequest(req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "debug: %s %s failed after %s: %v\n", req.Method, req.URL, elapsed, err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "debug: %s %s: %s in %s\n", req.Method, req.URL, resp.Status, elapsed)
+}
+
+// versionString returns the string representation of the version for output, honoring -no-v-prefix.
+func versionString(v *version.Version) string {
+	if noVPrefixFlag {
+		return strings.TrimPrefix(v.String(), "v")
+	}
+	return "v" + strings.TrimPrefix(v.String(), "v")
+}
+
+// recordSeparator returns the string written after each printed version, honoring -0.
+func recordSeparator() string {
+	if nulFlag {
+		return "\x00"
+	}
+	return "\n"
+}
+
+// printRecord writes the version as a CSV or TSV row, preceded by a header row on the first call.
+func printRecord(v *version.Version) {
+	if !csvHeaderDone {
+		_ = outputCSV.Write([]string{"version", "core", "prerelease", "k0s build", "url"})
+		csvHeaderDone = true
+	}
+	var build string
+	if n, ok := v.K0s(); ok {
+		build = strconv.Itoa(n)
+	}
+	core := fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch())
+	_ = outputCSV.Write([]string{versionString(v), core, v.Prerelease(), build, v.URL()})
+	outputCSV.Flush()
+	if err := outputCSV.Error(); err != nil {
+		println("failed to write output:", err.Error())
+		os.Exit(1)
+	}
+}
+
+func printVersion(v *version.Version) {
+	if outputCSV != nil {
+		printRecord(v)
+		return
+	}
+	if outputTemplate == nil {
+		fmt.Print(versionString(v), recordSeparator())
+		return
+	}
+	if err := outputTemplate.Execute(os.Stdout, v); err != nil {
+		println("failed to execute format template:", err.Error())
+		os.Exit(1)
+	}
+	fmt.Print(recordSeparator())
+}
+
+// scanNUL is a bufio.SplitFunc that splits the input into NUL terminated records.
+func scanNUL(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// open opens a local file or, for http(s) URLs, fetches it using the client.
+func open(name string) (io.ReadCloser, error) {
+	if strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://") {
+		return client.Open(context.Background(), name)
+	}
+	return os.Open(name)
+}
+
 func online() {
-	v, err := version.LatestByPrerelease(!stableOnlyFlag)
+	v, err := client.LatestByPrerelease(context.Background(), !stableOnlyFlag)
 	if err != nil {
 		println("failed to get latest version:", err.Error())
 		os.Exit(1)
 	}
-	fmt.Println(v.String())
+	printVersion(v)
 }
 
 func main() {
 	flag.Usage = func() {
 		exe, _ := os.Executable()
-		fmt.Fprintf(os.Stderr, "Usage: %s [options] [filename ...]\n", filepath.Base(exe))
+		fmt.Fprintf(os.Stderr, "Usage: %s [options] [filename|url ...]\n", filepath.Base(exe))
+		fmt.Fprintf(os.Stderr, "       %s -watch [-interval <duration>] [-exec <command>] [constraint]\n", filepath.Base(exe))
+		fmt.Fprintf(os.Stderr, "       %s check [-q|-v] <constraint> <version ...>\n", filepath.Base(exe))
+		fmt.Fprintf(os.Stderr, "       %s latest [-stable] [-satisfying <constraint>]\n", filepath.Base(exe))
+		fmt.Fprintf(os.Stderr, "       %s releases [-stable] [-satisfying <constraint>] [-wide|-json]\n", filepath.Base(exe))
+		fmt.Fprintf(os.Stderr, "       %s compare [-human] <version> <version>\n", filepath.Base(exe))
+		fmt.Fprintf(os.Stderr, "       %s lint [filename|url ...]\n", filepath.Base(exe))
+		fmt.Fprintf(os.Stderr, "       %s fmt [-w] [-prefix <prefix>] [filename ...]\n", filepath.Base(exe))
+		fmt.Fprintf(os.Stderr, "       %s completion bash|zsh|fish\n", filepath.Base(exe))
 		flag.PrintDefaults()
 	}
 	flag.BoolVar(&versionFlag, "v", false, "print k0s_sort version")
 	flag.BoolVar(&latestFlag, "l", false, "only print the latest version from input")
 	flag.BoolVar(&onlineFlag, "o", false, "print the latest version from online")
 	flag.BoolVar(&stableOnlyFlag, "s", false, "omit prerelease versions")
+	flag.BoolVar(&reverseFlag, "r", false, "sort in descending order")
+	flag.BoolVar(&uniqueFlag, "unique", false, "omit duplicate versions")
+	flag.BoolVar(&watchFlag, "watch", false, "poll online for new releases matching an optional constraint argument")
+	flag.DurationVar(&intervalFlag, "interval", time.Hour, "polling interval for -watch")
+	flag.StringVar(&execFlag, "exec", "", "command to execute with each new version as its argument in -watch mode")
+	flag.StringVar(&satisfyingFlag, "satisfying", "", "only print versions from input that satisfy the constraint")
+	flag.BoolVar(&noVPrefixFlag, "no-v-prefix", false, "print versions without the v prefix")
+	flag.BoolVar(&nulFlag, "0", false, "read and write NUL separated records instead of lines")
+	flag.StringVar(&outputFlag, "output", "", "print versions as csv or tsv with the columns version, core, prerelease, k0s build and url")
+	flag.BoolVar(&debugFlag, "debug", false, "print HTTP requests made by the library to stderr")
+	flag.StringVar(&formatFlag, "format", "", "format output using a go template, eg '{{.Base}} {{.DownloadURL \"linux\" \"amd64\"}}'")
 	flag.Parse()
 
+	if formatFlag != "" {
+		tmpl, err := template.New("format").Parse(formatFlag)
+		if err != nil {
+			println("invalid format template:", err.Error())
+			os.Exit(1)
+		}
+		outputTemplate = tmpl
+	}
+
+	switch outputFlag {
+	case "":
+	case "csv", "tsv":
+		if outputTemplate != nil || nulFlag {
+			println("-output can't be used with -format or -0")
+			os.Exit(1)
+		}
+		outputCSV = csv.NewWriter(os.Stdout)
+		if outputFlag == "tsv" {
+			outputCSV.Comma = '\t'
+		}
+	default:
+		println("invalid output format:", outputFlag)
+		os.Exit(1)
+	}
+
+	clientOpts := []version.ClientOption{version.WithOffline(version.IsOffline())}
+	if debugFlag {
+		clientOpts = append(clientOpts, version.WithRequestObserver(debugRequest))
+	}
+	if watchFlag {
+		// Every poll must see the current release list.
+		clientOpts = append(clientOpts, version.WithCacheMaxAge(0))
+	}
+	client = version.NewClient(clientOpts...)
+
 	if versionFlag {
 		fmt.Println(toolversion.Version)
 		return
@@ -52,11 +209,42 @@ func main() {
 		return
 	}
 
+	if watchFlag {
+		os.Exit(watch(strings.Join(flag.Args(), " "), intervalFlag, execFlag))
+	}
+
+	switch flag.Arg(0) {
+	case "check":
+		os.Exit(check(flag.Args()[1:]))
+	case "latest":
+		os.Exit(latest(flag.Args()[1:]))
+	case "releases":
+		os.Exit(releases(flag.Args()[1:]))
+	case "compare":
+		os.Exit(compare(flag.Args()[1:]))
+	case "lint":
+		os.Exit(lint(flag.Args()[1:]))
+	case "fmt":
+		os.Exit(formatFiles(flag.Args()[1:]))
+	case "completion":
+		os.Exit(completion(flag.Args()[1:]))
+	}
+
+	var constraint version.Constraints
+	if satisfyingFlag != "" {
+		c, err := version.NewConstraint(satisfyingFlag)
+		if err != nil {
+			println(err.Error())
+			os.Exit(1)
+		}
+		constraint = c
+	}
+
 	var input io.Reader
 	if flag.NArg() > 0 && flag.Arg(0) != "-" {
 		var files []io.Reader
 		for _, fn := range flag.Args() {
-			file, err := os.Open(fn)
+			file, err := open(fn)
 			if err != nil {
 				println("can't open file:", err.Error())
 				os.Exit(1)
@@ -79,6 +267,9 @@ func main() {
 	}
 	versions := version.Collection{}
 	scanner := bufio.NewScanner(input)
+	if nulFlag {
+		scanner.Split(scanNUL)
+	}
 	for scanner.Scan() {
 		v, err := version.NewVersion(scanner.Text())
 		if err != nil {
@@ -88,17 +279,37 @@ func main() {
 		if v.Prerelease() != "" && stableOnlyFlag {
 			continue
 		}
+		if constraint != nil && !constraint.Check(v) {
+			continue
+		}
 		versions = append(versions, v)
 	}
 
-	sort.Sort(versions)
+	versions = versions.Sorted()
 
 	if latestFlag && len(versions) > 0 {
-		fmt.Printf("v%s\n", strings.TrimPrefix(versions[len(versions)-1].String(), "v"))
+		printVersion(versions[len(versions)-1])
 		return
 	}
 
+	if uniqueFlag {
+		unique := versions[:0]
+		for i, v := range versions {
+			if i > 0 && v.Equal(versions[i-1]) {
+				continue
+			}
+			unique = append(unique, v)
+		}
+		versions = unique
+	}
+
+	if reverseFlag {
+		for i, j := 0, len(versions)-1; i < j; i, j = i+1, j-1 {
+			versions[i], versions[j] = versions[j], versions[i]
+		}
+	}
+
 	for _, v := range versions {
-		fmt.Printf("v%s\n", strings.TrimPrefix(v.String(), "v"))
+		printVersion(v)
 	}
 }
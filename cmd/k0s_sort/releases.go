@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/k0sproject/version"
+)
+
+// releases lists the online releases matching an optional constraint and returns an exit code.
+func releases(args []string) int {
+	fs := flag.NewFlagSet("releases", flag.ExitOnError)
+	stable := fs.Bool("stable", false, "omit prereleases")
+	satisfying := fs.String("satisfying", "", "only list releases that satisfy the constraint")
+	wide := fs.Bool("wide", false, "print the release date and prerelease flag of each release")
+	asJSON := fs.Bool("json", false, "print the releases as a JSON array")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: k0s_sort releases [-stable] [-satisfying <constraint>] [-wide|-json]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if *wide && *asJSON {
+		println("-wide can't be used with -json")
+		return exitInvalid
+	}
+
+	var c version.Constraints
+	if *satisfying != "" {
+		var err error
+		c, err = version.NewConstraint(*satisfying)
+		if err != nil {
+			println(err.Error())
+			return exitInvalid
+		}
+	}
+
+	list, err := client.ReleaseList(context.Background())
+	if err != nil {
+		println("failed to list releases:", err.Error())
+		return 1
+	}
+
+	matching := []version.Release{}
+	for _, r := range list {
+		if *stable && (r.Prerelease || r.Version.IsPrerelease()) {
+			continue
+		}
+		if c != nil && !c.Check(r.Version) {
+			continue
+		}
+		matching = append(matching, r)
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		return matching[i].Version.LessThan(matching[j].Version)
+	})
+
+	switch {
+	case *asJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(matching); err != nil {
+			println("failed to write output:", err.Error())
+			return 1
+		}
+	case *wide:
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tPUBLISHED\tPRERELEASE")
+		for _, r := range matching {
+			fmt.Fprintf(w, "%s\t%s\t%t\n", versionString(r.Version), r.PublishedAt.Format("2006-01-02"), r.Prerelease)
+		}
+		if err := w.Flush(); err != nil {
+			println("failed to write output:", err.Error())
+			return 1
+		}
+	default:
+		for _, r := range matching {
+			printVersion(r.Version)
+		}
+	}
+
+	if c != nil && len(matching) == 0 {
+		println("no release satisfying", c.String(), "found")
+		return exitUnsatisfied
+	}
+	return 0
+}
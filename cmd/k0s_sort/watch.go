@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/k0sproject/version"
+)
+
+// watch polls the release list and prints each release that satisfies the optional constraint and
+// was not listed in the previous poll. At startup, only the latest satisfying release is printed.
+// If hook is set, it is executed with the version as its argument for each printed release.
+func watch(constraint string, interval time.Duration, hook string) int {
+	if interval <= 0 {
+		println("-interval must be positive, got", interval.String())
+		return exitInvalid
+	}
+
+	var c version.Constraints
+	if constraint != "" {
+		var err error
+		c, err = version.NewConstraint(constraint)
+		if err != nil {
+			println(err.Error())
+			return exitInvalid
+		}
+	}
+
+	var seen map[string]struct{}
+	for {
+		releases, err := client.Releases(context.Background())
+		if err != nil {
+			println("failed to list releases:", err.Error())
+			time.Sleep(interval)
+			continue
+		}
+
+		var found version.Collection
+		for _, v := range releases {
+			if stableOnlyFlag && v.IsPrerelease() {
+				continue
+			}
+			if c != nil && !c.Check(v) {
+				continue
+			}
+			if _, ok := seen[v.String()]; !ok {
+				found = append(found, v)
+			}
+		}
+		found = found.Sorted()
+		if seen == nil && len(found) > 0 {
+			found = found[len(found)-1:]
+		}
+
+		if seen == nil {
+			seen = make(map[string]struct{}, len(releases))
+		}
+		for _, v := range releases {
+			seen[v.String()] = struct{}{}
+		}
+
+		for _, v := range found {
+			printVersion(v)
+			if hook != "" {
+				cmd := exec.Command(hook, v.String())
+				cmd.Stdout = os.Stdout
+				cmd.Stderr = os.Stderr
+				cmd.Env = append(os.Environ(), "K0S_VERSION="+v.String())
+				if err := cmd.Run(); err != nil {
+					println("hook failed:", err.Error())
+				}
+			}
+		}
+		time.Sleep(interval)
+	}
+}
@@ -1,7 +1,10 @@
 package version
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // Collection is a type that implements the sort.Interface interface
@@ -13,13 +16,48 @@ func NewCollection(versions ...string) (Collection, error) {
 	for i, v := range versions {
 		nv, err := NewVersion(v)
 		if err != nil {
-			return Collection{}, fmt.Errorf("invalid version '%s': %w", v, err)
+			return Collection{}, fmt.Errorf("'%s': %w", v, err)
 		}
 		c[i] = nv
 	}
 	return c, nil
 }
 
+// Strings returns the string representations of the versions in the collection.
+func (c Collection) Strings() []string {
+	s := make([]string, len(c))
+	for i, v := range c {
+		s[i] = v.String()
+	}
+	return s
+}
+
+// String returns the versions in the collection joined with commas.
+func (c Collection) String() string {
+	return strings.Join(c.Strings(), ", ")
+}
+
+type detailedVersion struct {
+	Version    string `json:"version"`
+	Prerelease bool   `json:"prerelease"`
+	K0s        *int   `json:"k0s,omitempty"`
+}
+
+// MarshalDetailedJSON returns the collection as a JSON array of objects containing the version,
+// whether it is a prerelease and the k0s build number (omitted for non-k0s versions), for example
+// [{"version":"v1.29.2+k0s.0","prerelease":false,"k0s":0}]. The regular JSON form of a collection
+// is an array of version strings.
+func (c Collection) MarshalDetailedJSON() ([]byte, error) {
+	details := make([]detailedVersion, len(c))
+	for i, v := range c {
+		details[i] = detailedVersion{Version: v.String(), Prerelease: v.IsPrerelease()}
+		if k0s, ok := v.K0s(); ok {
+			details[i].K0s = &k0s
+		}
+	}
+	return json.Marshal(details)
+}
+
 func (c Collection) Len() int {
 	return len(c)
 }
@@ -31,3 +69,86 @@ func (c Collection) Less(i, j int) bool {
 func (c Collection) Swap(i, j int) {
 	c[i], c[j] = c[j], c[i]
 }
+
+// Sorted returns a sorted copy of the collection. Unlike sort.Sort, versions with equal precedence,
+// such as ones that only differ by metadata, are ordered by their metadata and then by their string
+// representation, so the result does not depend on the order of the input.
+func (c Collection) Sorted() Collection {
+	sorted := make(Collection, len(c))
+	copy(sorted, c)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sortedLess(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+// sortedLess reports whether a sorts before b in Sorted.
+func sortedLess(a, b *Version) bool {
+	if cmp := a.Compare(b); cmp != 0 || a == nil {
+		return cmp < 0
+	}
+	if a.meta != b.meta {
+		return a.meta < b.meta
+	}
+	return a.String() < b.String()
+}
+
+// Negotiate returns the newest version that is present in both collections. It can be used to pick
+// a common version between components that each advertise the versions they support.
+func Negotiate(a, b Collection) (*Version, error) {
+	return NegotiateMatching(a, b, nil)
+}
+
+// NegotiateMatching is like Negotiate but only considers versions matched by m. A nil matcher
+// matches all versions.
+func NegotiateMatching(a, b Collection, m VersionMatcher) (*Version, error) {
+	var best *Version
+	for _, v := range a {
+		if m != nil && !m.Check(v) {
+			continue
+		}
+		if best != nil && !v.GreaterThan(best) {
+			continue
+		}
+		for _, w := range b {
+			if v.Equal(w) {
+				best = v
+				break
+			}
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("%w: no common version found", ErrNoVersions)
+	}
+	return best, nil
+}
+
+// SelectMinimal returns the lowest version in the collection that satisfies all of the constraints,
+// in the spirit of minimal version selection.
+func SelectMinimal(c Collection, constraints ...Constraints) (*Version, error) {
+	return selectVersion(c, constraints, -1)
+}
+
+// SelectLatest returns the highest version in the collection that satisfies all of the constraints.
+func SelectLatest(c Collection, constraints ...Constraints) (*Version, error) {
+	return selectVersion(c, constraints, 1)
+}
+
+func selectVersion(c Collection, constraints []Constraints, want int) (*Version, error) {
+	var selected *Version
+candidates:
+	for _, v := range c {
+		for _, cs := range constraints {
+			if !cs.Check(v) {
+				continue candidates
+			}
+		}
+		if selected == nil || v.Compare(selected) == want {
+			selected = v
+		}
+	}
+	if selected == nil {
+		return nil, fmt.Errorf("%w: no version satisfies the constraints", ErrNoVersions)
+	}
+	return selected, nil
+}
@@ -2,6 +2,8 @@ package version_test
 
 import (
 	"encoding/json"
+	"fmt"
+	"math/rand"
 	"sort"
 	"testing"
 
@@ -18,6 +20,15 @@ func TestNewCollection(t *testing.T) {
 	Error(t, err)
 }
 
+func TestCollectionStrings(t *testing.T) {
+	c, err := version.NewCollection("1.23.3+k0s.1", "v1.23.4")
+	NoError(t, err)
+	Equal(t, []string{"v1.23.3+k0s.1", "v1.23.4"}, c.Strings())
+	Equal(t, "v1.23.3+k0s.1, v1.23.4", c.String())
+	Equal(t, "candidates: v1.23.3+k0s.1, v1.23.4", fmt.Sprintf("candidates: %s", c))
+	Equal(t, "", version.Collection{}.String())
+}
+
 func TestSorting(t *testing.T) {
 	c, err := version.NewCollection(
 		"1.21.2+k0s.0",
@@ -35,6 +46,137 @@ func TestSorting(t *testing.T) {
 	Equal(t, "v1.21.2+k0s.0", c[4].String())
 }
 
+func TestSorted(t *testing.T) {
+	c, err := version.NewCollection(
+		"1.21.2+k0s.0.b",
+		"1.21.2+k0s.0.a",
+		"1.21.1+k0s.1",
+		"1.21.2+k0s.0.c",
+	)
+	NoError(t, err)
+	sorted := c.Sorted()
+	Equal(t, "v1.21.1+k0s.1", sorted[0].String())
+	Equal(t, "v1.21.2+k0s.0.a", sorted[1].String())
+	Equal(t, "v1.21.2+k0s.0.b", sorted[2].String())
+	Equal(t, "v1.21.2+k0s.0.c", sorted[3].String())
+	// original is untouched
+	Equal(t, "v1.21.2+k0s.0.b", c[0].String())
+
+	reversed := version.Collection{c[3], c[2], c[1], c[0]}
+	Equal(t, sorted, reversed.Sorted())
+}
+
+func TestSortedLarge(t *testing.T) {
+	var c version.Collection
+	for minor := 30; minor >= 20; minor-- {
+		for patch := 5; patch >= 0; patch-- {
+			c = append(c,
+				version.MustParse(fmt.Sprintf("v1.%d.%d+k0s.1", minor, patch)),
+				version.MustParse(fmt.Sprintf("v1.%d.%d-rc.1+k0s.0", minor, patch)),
+				version.MustParse(fmt.Sprintf("v1.%d.%d", minor, patch)),
+				version.MustParse(fmt.Sprintf("v1.%d.%d+k0s.0", minor, patch)),
+			)
+		}
+	}
+	c = append(c, nil)
+
+	sorted := c.Sorted()
+	Equal(t, len(c), len(sorted))
+	True(t, sorted[0] == nil)
+	for i := 2; i < len(sorted); i++ {
+		True(t, sorted[i-1].LessThan(sorted[i]))
+	}
+}
+
+func TestSortedOrder(t *testing.T) {
+	versions := []string{
+		"1", "1.0", "1.0.0", "1.2", "1.2.0", "1.2.3", "0.0.1", "2", "2.0.0-alpha", "2.0.0-alpha.1",
+		"2.0.0-beta", "2.0.0-rc.1", "2.0.0-rc.10", "2.0.0-rc.2", "2.0.0", "v2.0.0", "2.0.0+build.1",
+		"2.0.0+build.2", "2.0.0+k0s.0", "2.0.0+k0s.1", "2.0.0+k0s.10", "2.0.0-rc.1+k0s.0",
+		"1.29.2+k0s.0", "1.29.2", "1.29.10", "65536.0.0", "20240101",
+	}
+	c := version.Collection{nil}
+	for _, s := range versions {
+		c = append(c, version.MustParse(s))
+	}
+	rand.New(rand.NewSource(1)).Shuffle(len(c), func(i, j int) { c[i], c[j] = c[j], c[i] })
+
+	sorted := c.Sorted()
+	True(t, sorted[0] == nil)
+	for i := 2; i < len(sorted); i++ {
+		a, b := sorted[i-1], sorted[i]
+		switch cmp := a.Compare(b); {
+		case cmp > 0:
+			t.Errorf("%s sorted before %s", a, b)
+		case cmp == 0 && a.Metadata() > b.Metadata():
+			t.Errorf("%s sorted before %s with lower metadata", a, b)
+		case cmp == 0 && a.Metadata() == b.Metadata() && a.String() > b.String():
+			t.Errorf("%s sorted before %s with the same metadata", a, b)
+		}
+	}
+}
+
+func BenchmarkSorted(b *testing.B) {
+	var c version.Collection
+	for minor := 0; minor < 40; minor++ {
+		for patch := 0; patch < 25; patch++ {
+			for _, pre := range []string{"", "-alpha.1", "-beta.1", "-rc.1", "-rc.2"} {
+				for build := 0; build < 4; build++ {
+					c = append(c, version.MustParse(fmt.Sprintf("v1.%d.%d%s+k0s.%d", minor, patch, pre, build)))
+				}
+			}
+		}
+	}
+	rand.New(rand.NewSource(1)).Shuffle(len(c), func(i, j int) { c[i], c[j] = c[j], c[i] })
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = c.Sorted()
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	a, err := version.NewCollection("1.27.1+k0s.0", "1.28.2+k0s.0", "1.29.0+k0s.0", "1.30.0-rc.1+k0s.0")
+	NoError(t, err)
+	b, err := version.NewCollection("1.30.0-rc.1+k0s.0", "1.28.2+k0s.0", "1.27.1+k0s.0", "1.29.0+k0s.1")
+	NoError(t, err)
+
+	v, err := version.Negotiate(a, b)
+	NoError(t, err)
+	Equal(t, "v1.30.0-rc.1+k0s.0", v.String())
+
+	v, err = version.NegotiateMatching(a, b, version.MustConstraint("< 1.30"))
+	NoError(t, err)
+	Equal(t, "v1.28.2+k0s.0", v.String())
+
+	_, err = version.NegotiateMatching(a, b, version.MustConstraint(">= 1.31"))
+	Error(t, err)
+
+	_, err = version.Negotiate(a, version.Collection{})
+	Error(t, err)
+}
+
+func TestSelect(t *testing.T) {
+	c, err := version.NewCollection("1.29.1+k0s.0", "1.27.1+k0s.0", "1.28.2+k0s.0", "1.28.0+k0s.0", "1.30.0-rc.1+k0s.0")
+	NoError(t, err)
+
+	v, err := version.SelectMinimal(c, version.MustConstraint(">= 1.28"), version.MustConstraint("< 1.30"))
+	NoError(t, err)
+	Equal(t, "v1.28.0+k0s.0", v.String())
+
+	v, err = version.SelectLatest(c, version.MustConstraint(">= 1.28"), version.MustConstraint("< 1.30"))
+	NoError(t, err)
+	Equal(t, "v1.29.1+k0s.0", v.String())
+
+	v, err = version.SelectMinimal(c)
+	NoError(t, err)
+	Equal(t, "v1.27.1+k0s.0", v.String())
+
+	_, err = version.SelectLatest(c, version.MustConstraint(">= 1.28"), version.MustConstraint("< 1.28"))
+	Error(t, err)
+}
+
 func TestCollectionMarshalling(t *testing.T) {
 	c, err := version.NewCollection("v1.0.0+k0s.0", "v1.0.1+k0s.0")
 	NoError(t, err)
@@ -46,6 +188,14 @@ func TestCollectionMarshalling(t *testing.T) {
 	})
 }
 
+func TestCollectionDetailedJSON(t *testing.T) {
+	c, err := version.NewCollection("v1.29.2+k0s.0", "v1.30.0-rc.1+k0s.1", "v1.0.0")
+	NoError(t, err)
+	jsonData, err := c.MarshalDetailedJSON()
+	NoError(t, err)
+	Equal(t, `[{"version":"v1.29.2+k0s.0","prerelease":false,"k0s":0},{"version":"v1.30.0-rc.1+k0s.1","prerelease":true,"k0s":1},{"version":"v1.0.0","prerelease":false}]`, string(jsonData))
+}
+
 func TestCollectionUnmarshalling(t *testing.T) {
 	t.Run("JSON", func(t *testing.T) {
 		var c version.Collection
@@ -9,9 +9,13 @@ import (
 
 var constraintRegex = regexp.MustCompile(`^(?:(>=|>|<=|<|!=|==?)\s*)?(.+)$`)
 
+// MaxConstraintLength is the maximum length of a constraint string accepted by NewConstraint.
+const MaxConstraintLength = 1024
+
 type constraintFunc func(a, b *Version) bool
 type constraint struct {
 	f        constraintFunc
+	op       string
 	b        *Version
 	original string
 }
@@ -20,8 +24,15 @@ type constraint struct {
 type Constraints []constraint
 
 // NewConstraint parses a string into a Constraints object that can be used to check
-// if a given version satisfies the constraint.
+// if a given version satisfies the constraint. Recently parsed constraint strings are cached, so
+// evaluating the same constraint repeatedly only parses it once.
 func NewConstraint(cs string) (Constraints, error) {
+	if len(cs) > MaxConstraintLength {
+		return Constraints{}, &LimitError{What: "constraint", Length: len(cs), Limit: MaxConstraintLength, Err: ErrInvalidConstraint}
+	}
+	if c, ok := parsedConstraints.get(cs); ok {
+		return c, nil
+	}
 	parts := strings.Split(cs, ",")
 	newC := make(Constraints, len(parts))
 	for i, p := range parts {
@@ -30,10 +41,11 @@ func NewConstraint(cs string) (Constraints, error) {
 	for i, p := range parts {
 		c, err := newConstraint(p)
 		if err != nil {
-			return Constraints{}, err
+			return Constraints{}, wrapCause(ErrInvalidConstraint, err, "%s '%s': %v", ErrInvalidConstraint, p, err)
 		}
 		newC[i] = c
 	}
+	parsedConstraints.put(cs, newC)
 
 	return newC, nil
 }
@@ -56,8 +68,12 @@ func (cs Constraints) String() string {
 	return strings.Join(s, ", ")
 }
 
-// Check returns true if the given version satisfies all of the constraints.
+// Check returns true if the given version satisfies all of the constraints. A nil version never
+// satisfies a constraint.
 func (cs Constraints) Check(v *Version) bool {
+	if v == nil {
+		return false
+	}
 	for _, c := range cs {
 		if c.b.Prerelease() == "" && v.Prerelease() != "" {
 			return false
@@ -88,7 +104,7 @@ func (c *constraint) String() string {
 func newConstraint(s string) (constraint, error) {
 	match := constraintRegex.FindStringSubmatch(s)
 	if len(match) != 3 {
-		return constraint{}, errors.New("invalid constraint: " + s)
+		return constraint{}, errors.New("no version")
 	}
 
 	op := match[1]
@@ -126,7 +142,11 @@ func newConstraint(s string) (constraint, error) {
 		return constraint{}, err
 	}
 
-	return constraint{f: f, b: target, original: s}, nil
+	if op == "" || op == "==" {
+		op = "="
+	}
+
+	return constraint{f: f, op: op, b: target, original: s}, nil
 }
 
 func opfunc(s string) (constraintFunc, error) {
@@ -154,4 +174,3 @@ func gte(a, b *Version) bool { return b.GreaterThanOrEqual(a) }
 func lte(a, b *Version) bool { return b.LessThanOrEqual(a) }
 func eq(a, b *Version) bool  { return b.Equal(a) }
 func neq(a, b *Version) bool { return !b.Equal(a) }
-
@@ -0,0 +1,58 @@
+package version
+
+import (
+	"container/list"
+	"sync"
+)
+
+// constraintCacheSize is the number of parsed constraint strings kept by NewConstraint.
+const constraintCacheSize = 256
+
+type constraintCacheEntry struct {
+	key string
+	cs  Constraints
+}
+
+// constraintCache is a least recently used cache of parsed constraints keyed by the original
+// constraint string. Only successfully parsed constraints are stored.
+type constraintCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func newConstraintCache(size int) *constraintCache {
+	return &constraintCache{size: size, order: list.New(), entries: make(map[string]*list.Element, size)}
+}
+
+var parsedConstraints = newConstraintCache(constraintCacheSize)
+
+// get returns a copy of the cached constraints for the string, so that callers can't modify the
+// cached value.
+func (c *constraintCache) get(key string) (Constraints, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	cs := el.Value.(*constraintCacheEntry).cs
+	return append(make(Constraints, 0, len(cs)), cs...), true
+}
+
+func (c *constraintCache) put(key string, cs Constraints) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&constraintCacheEntry{key: key, cs: append(make(Constraints, 0, len(cs)), cs...)})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*constraintCacheEntry).key)
+	}
+}
@@ -0,0 +1,105 @@
+package version
+
+import (
+	"fmt"
+	"testing"
+)
+
+// withEmptyConstraintCache replaces parsedConstraints with an empty cache for the duration of the test.
+func withEmptyConstraintCache(t *testing.T) {
+	t.Helper()
+	orig := parsedConstraints
+	parsedConstraints = newConstraintCache(constraintCacheSize)
+	t.Cleanup(func() { parsedConstraints = orig })
+}
+
+func cacheKey(i int) string {
+	return fmt.Sprintf(">= 1.%d.0", i)
+}
+
+func TestConstraintCacheEviction(t *testing.T) {
+	withEmptyConstraintCache(t)
+
+	for i := 0; i <= constraintCacheSize; i++ {
+		if _, err := NewConstraint(cacheKey(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := len(parsedConstraints.entries); n != constraintCacheSize {
+		t.Fatalf("expected %d cached constraints, got %d", constraintCacheSize, n)
+	}
+	if _, ok := parsedConstraints.get(cacheKey(0)); ok {
+		t.Fatal("expected the oldest constraint to be evicted")
+	}
+	for i := 1; i <= constraintCacheSize; i++ {
+		if _, ok := parsedConstraints.get(cacheKey(i)); !ok {
+			t.Fatalf("expected %q to be cached", cacheKey(i))
+		}
+	}
+}
+
+func TestConstraintCacheRecentlyUsed(t *testing.T) {
+	withEmptyConstraintCache(t)
+
+	for i := 0; i < constraintCacheSize; i++ {
+		if _, err := NewConstraint(cacheKey(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// parsing the oldest key again is a hit that makes it the most recently used one
+	if _, err := NewConstraint(cacheKey(0)); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(parsedConstraints.entries); n != constraintCacheSize {
+		t.Fatalf("expected a repeated key to hit the cache, got %d entries", n)
+	}
+	if _, err := NewConstraint(cacheKey(constraintCacheSize)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := parsedConstraints.get(cacheKey(0)); !ok {
+		t.Fatal("expected the recently used constraint to stay cached")
+	}
+	if _, ok := parsedConstraints.get(cacheKey(1)); ok {
+		t.Fatal("expected the least recently used constraint to be evicted")
+	}
+}
+
+func TestConstraintCacheSkipsInvalid(t *testing.T) {
+	withEmptyConstraintCache(t)
+
+	for i := 0; i < 2; i++ {
+		if _, err := NewConstraint(">= x"); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+	if _, ok := parsedConstraints.get(">= x"); ok {
+		t.Fatal("expected an invalid constraint not to be cached")
+	}
+}
+
+func TestConstraintCacheReturnsCopies(t *testing.T) {
+	withEmptyConstraintCache(t)
+
+	const cs = ">= 1.28.0, < 1.30.0"
+	replacement := MustConstraint("< 1.0.0")[0]
+
+	// the first call returns the freshly parsed value, the second one comes from the cache
+	for i := 0; i < 2; i++ {
+		c, err := NewConstraint(cs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c[0] = replacement
+	}
+
+	c, err := NewConstraint(cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.String() != cs {
+		t.Fatalf("expected %q, got %q", cs, c.String())
+	}
+	if !c.CheckString("1.29.1") {
+		t.Fatal("expected 1.29.1 to satisfy the constraint")
+	}
+}
@@ -143,6 +143,13 @@ func TestInvalidConstraint(t *testing.T) {
 	}
 }
 
+func TestCheckNil(t *testing.T) {
+	for _, c := range []string{"= 1.0.0", "!= 1.0.0", "> 1.0.0", ">= 1.0.0", "< 1.0.0", "<= 1.0.0", ">= 1.0.0-rc.1"} {
+		False(t, version.MustConstraint(c).Check(nil))
+	}
+	False(t, version.Constraints{}.Check(nil))
+}
+
 func TestCheckString(t *testing.T) {
 	c, err := version.NewConstraint(">= 1.0.0")
 	NoError(t, err)
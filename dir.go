@@ -0,0 +1,57 @@
+package version
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// NewCollectionFromDir returns a sorted collection of the versions found in the names of the k0s
+// binaries (k0s-<version>-<arch>[.exe]) and airgap bundles (k0s-airgap-bundle-<version>-<arch>)
+// in the directory. Files that don't follow the naming convention are ignored and each version
+// is included only once.
+func NewCollectionFromDir(dir string) (Collection, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return Collection{}, fmt.Errorf("read version directory: %w", err)
+	}
+
+	seen := make(map[string]struct{})
+	c := Collection{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		v, ok := versionFromAssetName(entry.Name())
+		if !ok {
+			continue
+		}
+		if _, ok := seen[v.String()]; ok {
+			continue
+		}
+		seen[v.String()] = struct{}{}
+		c = append(c, v)
+	}
+
+	return c.Sorted(), nil
+}
+
+func versionFromAssetName(name string) (*Version, bool) {
+	switch {
+	case strings.HasPrefix(name, "k0s-airgap-bundle-"):
+		name = strings.TrimPrefix(name, "k0s-airgap-bundle-")
+	case strings.HasPrefix(name, "k0s-"):
+		name = strings.TrimSuffix(strings.TrimPrefix(name, "k0s-"), ".exe")
+	default:
+		return nil, false
+	}
+	idx := strings.LastIndex(name, "-")
+	if idx <= 0 {
+		return nil, false
+	}
+	v, err := NewVersion(name[:idx])
+	if err != nil {
+		return nil, false
+	}
+	return v, true
+}
@@ -0,0 +1,37 @@
+package version_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k0sproject/version"
+)
+
+func TestNewCollectionFromDir(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"k0s-v1.29.2+k0s.0-amd64",
+		"k0s-v1.29.2+k0s.0-arm64",
+		"k0s-v1.29.2+k0s.0-amd64.exe",
+		"k0s-airgap-bundle-v1.28.6+k0s.0-amd64",
+		"k0s-v1.30.0-rc.1+k0s.0-amd64",
+		"k0s-airgap-bundle-v1.27.1+k0s.0-arm",
+		"k0s-invalid-amd64",
+		"README.md",
+	} {
+		NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+	NoError(t, os.Mkdir(filepath.Join(dir, "k0s-v1.31.0+k0s.0-amd64"), 0o755))
+
+	c, err := version.NewCollectionFromDir(dir)
+	NoError(t, err)
+	Equal(t, 4, len(c))
+	Equal(t, "v1.27.1+k0s.0", c[0].String())
+	Equal(t, "v1.28.6+k0s.0", c[1].String())
+	Equal(t, "v1.29.2+k0s.0", c[2].String())
+	Equal(t, "v1.30.0-rc.1+k0s.0", c[3].String())
+
+	_, err = version.NewCollectionFromDir(filepath.Join(dir, "missing"))
+	Error(t, err)
+}
@@ -0,0 +1,84 @@
+package version
+
+// MinorsBetween returns the number of minor versions from v to b (eg 3 from v1.26.1 to v1.29.0) and
+// true. The result is negative when b is on a lower release line than v. The number of minors in a
+// previous major version can't be known, so when the major versions differ it returns 0 and false.
+// Use Collection.MinorsBetween for an exact count based on known versions.
+func (v *Version) MinorsBetween(b *Version) (int, bool) {
+	if v.Major() != b.Major() {
+		return 0, false
+	}
+	return b.Minor() - v.Minor(), true
+}
+
+// PatchesBetween returns the number of patch versions from v to b (eg 2 from v1.29.1 to v1.29.3) and
+// true. The result is negative when b has a lower patch number than v. When the versions are on
+// different release lines it returns 0 and false. Use Collection.PatchesBetween for an exact count
+// based on known versions.
+func (v *Version) PatchesBetween(b *Version) (int, bool) {
+	if v.MajorMinor() != b.MajorMinor() {
+		return 0, false
+	}
+	return b.Patch() - v.Patch(), true
+}
+
+// MinorsBetween returns the number of distinct release lines in the collection that are above the
+// release line of a and up to and including the release line of b. The result is negative when b
+// is on a lower release line than a.
+func (c Collection) MinorsBetween(a, b *Version) int {
+	from, to := a.MajorMinor(), b.MajorMinor()
+	sign := 1
+	if mmLess(to, from) {
+		from, to = to, from
+		sign = -1
+	}
+	seen := make(map[MajorMinor]struct{})
+	for _, v := range c {
+		if v == nil {
+			continue
+		}
+		mm := v.MajorMinor()
+		if mmLess(from, mm) && !mmLess(to, mm) {
+			seen[mm] = struct{}{}
+		}
+	}
+	return sign * len(seen)
+}
+
+// PatchesBetween returns the number of distinct major.minor.patch versions in the collection that
+// are above a and up to and including b. Prereleases, k0s build and metadata differences are not
+// counted. The result is negative when b is lower than a.
+func (c Collection) PatchesBetween(a, b *Version) int {
+	from, to := a.segments, b.segments
+	sign := 1
+	if segmentsLess(to, from) {
+		from, to = to, from
+		sign = -1
+	}
+	seen := make(map[[maxSegments]int]struct{})
+	for _, v := range c {
+		if v == nil || v.IsPrerelease() {
+			continue
+		}
+		if segmentsLess(from, v.segments) && !segmentsLess(to, v.segments) {
+			seen[v.segments] = struct{}{}
+		}
+	}
+	return sign * len(seen)
+}
+
+func mmLess(a, b MajorMinor) bool {
+	if a.Major != b.Major {
+		return a.Major < b.Major
+	}
+	return a.Minor < b.Minor
+}
+
+func segmentsLess(a, b [maxSegments]int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/k0sproject/version"
+)
+
+func TestVersionDistance(t *testing.T) {
+	a := version.MustParse("v1.26.1+k0s.0")
+
+	n, ok := a.MinorsBetween(version.MustParse("v1.29.0+k0s.0"))
+	True(t, ok)
+	Equal(t, 3, n)
+
+	n, ok = version.MustParse("v1.29.0").MinorsBetween(a)
+	True(t, ok)
+	Equal(t, -3, n)
+
+	_, ok = a.MinorsBetween(version.MustParse("v2.0.0"))
+	False(t, ok)
+
+	n, ok = a.PatchesBetween(version.MustParse("v1.26.3+k0s.1"))
+	True(t, ok)
+	Equal(t, 2, n)
+
+	_, ok = a.PatchesBetween(version.MustParse("v1.27.0"))
+	False(t, ok)
+}
+
+func TestCollectionDistance(t *testing.T) {
+	c, err := version.NewCollection(
+		"v1.28.4+k0s.0",
+		"v1.29.0+k0s.0",
+		"v1.29.1+k0s.0",
+		"v1.29.1+k0s.1",
+		"v1.29.2-rc.1+k0s.0",
+		"v1.29.2+k0s.0",
+		"v2.0.0+k0s.0",
+		"v2.1.0+k0s.0",
+	)
+	NoError(t, err)
+
+	a := version.MustParse("v1.28.4+k0s.0")
+	b := version.MustParse("v2.1.0+k0s.0")
+	Equal(t, 3, c.MinorsBetween(a, b))
+	Equal(t, -3, c.MinorsBetween(b, a))
+	Equal(t, 0, c.MinorsBetween(a, a))
+
+	Equal(t, 3, c.PatchesBetween(a, version.MustParse("v1.29.2+k0s.0")))
+	Equal(t, -5, c.PatchesBetween(b, a))
+}
+
+func TestCollectionDistanceNilEntries(t *testing.T) {
+	c := version.Collection{
+		version.MustParse("v1.28.4+k0s.0"),
+		nil,
+		version.MustParse("v1.29.0+k0s.0"),
+		nil,
+		version.MustParse("v1.29.1+k0s.0"),
+	}
+	a := version.MustParse("v1.28.4+k0s.0")
+	b := version.MustParse("v1.29.1+k0s.0")
+	Equal(t, 1, c.MinorsBetween(a, b))
+	Equal(t, 2, c.PatchesBetween(a, b))
+}
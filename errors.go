@@ -0,0 +1,54 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidVersion is returned when a version string can't be parsed.
+	ErrInvalidVersion = errors.New("invalid version")
+	// ErrInvalidConstraint is returned when a constraint string can't be parsed.
+	ErrInvalidConstraint = errors.New("invalid constraint")
+	// ErrNoVersions is returned when no version matches the requirements of an operation.
+	ErrNoVersions = errors.New("no matching versions")
+	// ErrDowngrade is returned when the target version is lower than the current version.
+	ErrDowngrade = errors.New("downgrade not allowed")
+	// ErrSkewViolation is returned when an upgrade is not allowed by a skew policy.
+	ErrSkewViolation = errors.New("version skew not allowed")
+	// ErrNotFound is returned when a remote resource does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrOffline is returned when online data would be required but offline mode is enabled.
+	ErrOffline = errors.New("offline mode enabled")
+	// ErrRateLimited is returned when the GitHub API rejects a request because the rate limit has
+	// been exhausted. Client.RateLimit reports when the limit resets.
+	ErrRateLimited = errors.New("github api rate limit exceeded")
+)
+
+// causeError is an error that matches a sentinel error with errors.Is while keeping the error that
+// caused it in the chain, so that errors.As still finds eg a *strconv.NumError.
+type causeError struct {
+	sentinel error
+	cause    error
+	msg      string
+}
+
+// wrapCause returns an error matching the sentinel and unwrapping to the cause. The message is
+// formatted from the format and args.
+func wrapCause(sentinel, cause error, format string, args ...interface{}) error {
+	return &causeError{sentinel: sentinel, cause: cause, msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *causeError) Error() string {
+	return e.msg
+}
+
+// Is reports whether the target is the sentinel error.
+func (e *causeError) Is(target error) bool {
+	return target == e.sentinel
+}
+
+// Unwrap returns the cause.
+func (e *causeError) Unwrap() error {
+	return e.cause
+}
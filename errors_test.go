@@ -0,0 +1,57 @@
+package version_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/k0sproject/version"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	for _, invalid := range []string{"", "v1.x", "1.2.3.4", "1.2.3_4", strings.Repeat("1", version.MaxIdentifierLength+1)} {
+		_, err := version.NewVersion(invalid)
+		True(t, errors.Is(err, version.ErrInvalidVersion))
+	}
+
+	_, err := version.NewCollection("1.2.3", "1.x")
+	True(t, errors.Is(err, version.ErrInvalidVersion))
+
+	_, err = version.ParseMajorMinor("1")
+	True(t, errors.Is(err, version.ErrInvalidVersion))
+
+	for _, invalid := range []string{"", ">= x", strings.Repeat(">= 1.0.0, ", version.MaxConstraintLength)} {
+		_, err := version.NewConstraint(invalid)
+		True(t, errors.Is(err, version.ErrInvalidConstraint))
+	}
+
+	_, err = version.NewRangeFromConstraint(version.MustConstraint("!= 1.0.0"))
+	True(t, errors.Is(err, version.ErrInvalidConstraint))
+
+	c, err := version.NewCollection("1.2.3", "1.2.4")
+	NoError(t, err)
+	_, err = version.SelectLatest(c, version.MustConstraint(">= 2.0"))
+	True(t, errors.Is(err, version.ErrNoVersions))
+	_, err = version.Negotiate(c, version.Collection{})
+	True(t, errors.Is(err, version.ErrNoVersions))
+}
+
+func TestErrorChain(t *testing.T) {
+	var numErr *strconv.NumError
+
+	_, err := version.NewVersion("1.x.3")
+	True(t, errors.Is(err, version.ErrInvalidVersion))
+	True(t, errors.As(err, &numErr))
+	Equal(t, "invalid version: parsing segment 'x': strconv.ParseUint: parsing \"x\": invalid syntax", err.Error())
+
+	_, err = version.ParseMajorMinor("1.x")
+	True(t, errors.Is(err, version.ErrInvalidVersion))
+	True(t, errors.As(err, &numErr))
+
+	_, err = version.NewConstraint(">= 1.x")
+	True(t, errors.Is(err, version.ErrInvalidConstraint))
+	True(t, errors.Is(err, version.ErrInvalidVersion))
+	True(t, errors.As(err, &numErr))
+	False(t, errors.Is(err, version.ErrNoVersions))
+}
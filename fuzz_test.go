@@ -0,0 +1,37 @@
+//go:build go1.18
+
+package version_test
+
+import (
+	"testing"
+
+	"github.com/k0sproject/version"
+)
+
+func FuzzNewVersion(f *testing.F) {
+	for _, seed := range []string{"v1.23.3+k0s.1", "1.2.3-rc.1+k0s.0.abc", "1", "1.2", "-", "+", "v", "1..2", "1.2.3-+"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := version.NewVersion(s)
+		if err != nil {
+			return
+		}
+		if _, err := version.NewVersion(v.String()); err != nil {
+			t.Errorf("string representation %q of %q does not parse: %v", v.String(), s, err)
+		}
+	})
+}
+
+func FuzzNewConstraint(f *testing.F) {
+	for _, seed := range []string{">= 1.2.3", "< 1.2-rc.1, > 1", "!= 1.0.0+k0s.1", "==", ">= 1.0-a"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		c, err := version.NewConstraint(s)
+		if err != nil {
+			return
+		}
+		c.Check(version.MustParse("1.2.3"))
+	})
+}
@@ -0,0 +1,69 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var hashicorpConstraintRegex = regexp.MustCompile(`^(~>|>=|<=|!=|=|>|<)?\s*(\S+)$`)
+
+// FromHashicorpConstraint converts a constraint string in the hashicorp/go-version syntax into
+// Constraints. In addition to the operators supported by NewConstraint, the pessimistic operator
+// ~> is translated into a range: "~> 1.2.3" becomes ">= 1.2.3, < 1.3.0" and "~> 1.2" becomes
+// ">= 1.2.0, < 2.0.0". Like in hashicorp/go-version, "~> 1" has no upper bound and becomes
+// ">= 1.0.0", and operands with fewer than three segments are padded with zeros, so "= 1.2" becomes
+// "= 1.2.0".
+//
+// When the operand is a prerelease, the upper bound gets the lowest possible prerelease (eg
+// "~> 1.2.3-rc.1" becomes ">= 1.2.3-rc.1, < 1.3.0-0") so that prereleases within the range,
+// including the operand itself, are accepted. Unlike hashicorp/go-version, this also accepts
+// prereleases of other patch versions within the range.
+func FromHashicorpConstraint(s string) (Constraints, error) {
+	var parts []string
+	for _, p := range strings.Split(s, ",") {
+		p = strings.TrimSpace(p)
+		match := hashicorpConstraintRegex.FindStringSubmatch(p)
+		if match == nil {
+			return Constraints{}, fmt.Errorf("%w '%s'", ErrInvalidConstraint, p)
+		}
+		v, err := NewVersion(match[2])
+		if err != nil {
+			return Constraints{}, wrapCause(ErrInvalidConstraint, err, "%s '%s': %v", ErrInvalidConstraint, p, err)
+		}
+		operand := padOperand(match[2])
+		if match[1] != "~>" {
+			parts = append(parts, strings.TrimSpace(match[1]+" "+operand))
+			continue
+		}
+		var upper string
+		switch len(v.Segments()) {
+		case 1:
+			parts = append(parts, ">= "+operand)
+			continue
+		case 2:
+			upper = fmt.Sprintf("%d.0.0", v.Major()+1)
+		default:
+			upper = fmt.Sprintf("%d.%d.0", v.Major(), v.Minor()+1)
+		}
+		if v.IsPrerelease() {
+			upper += "-0"
+		}
+		parts = append(parts, ">= "+operand, "< "+upper)
+	}
+	return NewConstraint(strings.Join(parts, ", "))
+}
+
+// padOperand pads the numeric part of a version string to three segments (eg "1.2-rc.1" becomes
+// "1.2.0-rc.1"), because hashicorp/go-version treats missing segments as zeros while NewConstraint
+// doesn't for every operator.
+func padOperand(s string) string {
+	core, rest := s, ""
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		core, rest = s[:i], s[i:]
+	}
+	for n := strings.Count(core, "."); n < 2; n++ {
+		core += ".0"
+	}
+	return core + rest
+}
@@ -0,0 +1,122 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/k0sproject/version"
+)
+
+func TestFromHashicorpConstraint(t *testing.T) {
+	testCases := []struct {
+		constraint string
+		expected   string
+		truthTable map[bool][]string
+	}{
+		{
+			constraint: "~> 1.2.3",
+			expected:   ">= 1.2.3, < 1.3.0",
+			truthTable: map[bool][]string{
+				true:  {"1.2.3", "1.2.9+k0s.1"},
+				false: {"1.2.2", "1.3.0"},
+			},
+		},
+		{
+			constraint: "~> 1.2",
+			expected:   ">= 1.2.0, < 2.0.0",
+			truthTable: map[bool][]string{
+				true:  {"1.2.0", "1.9.9"},
+				false: {"1.1.9", "2.0.0"},
+			},
+		},
+		{
+			constraint: "~> 1",
+			expected:   ">= 1.0.0",
+			truthTable: map[bool][]string{
+				true:  {"1.0.0", "1.9.9", "2.0.0", "3.1.0"},
+				false: {"0.9.9", "2.0.0-rc.1"},
+			},
+		},
+		{
+			constraint: "~> 1.2.3-rc.1",
+			expected:   ">= 1.2.3-rc.1, < 1.3.0-0",
+			truthTable: map[bool][]string{
+				true:  {"1.2.3-rc.1", "1.2.3-rc.2", "1.2.3", "1.2.9+k0s.1"},
+				false: {"1.2.3-beta.1", "1.2.2", "1.3.0-rc.1", "1.3.0"},
+			},
+		},
+		{
+			constraint: "~> 1.2-rc.1",
+			expected:   ">= 1.2.0-rc.1, < 2.0.0-0",
+			truthTable: map[bool][]string{
+				true:  {"1.2.0-rc.1", "1.2.0", "1.9.9"},
+				false: {"1.1.9", "2.0.0-rc.1", "2.0.0"},
+			},
+		},
+		{
+			constraint: "= 1.2",
+			expected:   "= 1.2.0",
+			truthTable: map[bool][]string{
+				true:  {"1.2.0"},
+				false: {"1.1.9", "1.2.1", "1.3.0"},
+			},
+		},
+		{
+			constraint: "1.2",
+			expected:   "1.2.0",
+			truthTable: map[bool][]string{
+				true:  {"1.2.0"},
+				false: {"1.1.9", "1.2.1"},
+			},
+		},
+		{
+			constraint: "!= 1",
+			expected:   "!= 1.0.0",
+			truthTable: map[bool][]string{
+				true:  {"0.9.9", "1.0.1", "2.0.0"},
+				false: {"1.0.0"},
+			},
+		},
+		{
+			constraint: "> 1.2",
+			expected:   "> 1.2.0",
+			truthTable: map[bool][]string{
+				true:  {"1.2.1", "1.3.0"},
+				false: {"1.1.9", "1.2.0"},
+			},
+		},
+		{
+			constraint: "<= 1.2-rc.1",
+			expected:   "<= 1.2.0-rc.1",
+			truthTable: map[bool][]string{
+				true:  {"1.1.9", "1.2.0-beta.1", "1.2.0-rc.1"},
+				false: {"1.2.0-rc.2", "1.2.0"},
+			},
+		},
+		{
+			constraint: ">= 1.0, != 1.1.0, < 1.2",
+			expected:   ">= 1.0.0, != 1.1.0, < 1.2.0",
+			truthTable: map[bool][]string{
+				true:  {"1.0.0", "1.1.1"},
+				false: {"1.1.0", "1.2.0"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			c, err := version.FromHashicorpConstraint(tc.constraint)
+			NoError(t, err)
+			Equal(t, tc.expected, c.String())
+			for expected, versions := range tc.truthTable {
+				for _, v := range versions {
+					Equal(t, expected, c.Check(version.MustParse(v)))
+				}
+			}
+		})
+	}
+
+	for _, invalid := range []string{"", "~>", "~> x", "=> 1.0"} {
+		_, err := version.FromHashicorpConstraint(invalid)
+		Error(t, err)
+	}
+}
@@ -1,35 +1,68 @@
 package version
 
 import (
-	"fmt"
-	"io"
+	"context"
+	"crypto/tls"
 	"net/http"
-	"net/url"
-	"strings"
+	"os"
+	"strconv"
+	"sync/atomic"
 	"time"
 )
 
+// OfflineEnv is the name of the environment variable that enables offline mode when set to a true value.
+const OfflineEnv = "K0S_VERSION_OFFLINE"
+
+// Timeout is the HTTP timeout used by the package level Latest* functions.
+//
+// Deprecated: Use NewClient with WithTimeout. Changing the variable while package level functions
+// are running is a data race.
 var Timeout = time.Second * 10
 
-// LatestByPrerelease returns the latest released k0s version, if preok is true, prereleases are also accepted.
-func LatestByPrerelease(allowpre bool) (*Version, error) {
-	u := &url.URL{
-		Scheme: "https",
-		Host:   "docs.k0sproject.io",
-	}
+// RequestObserver, when set, is called after every HTTP request the package level Latest* functions
+// make with the request, the response (nil on failure), the error (if any) and the time the request took.
+//
+// Deprecated: Use NewClient with WithRequestObserver. Changing the variable while package level
+// functions are running is a data race.
+var RequestObserver func(req *http.Request, resp *http.Response, err error, elapsed time.Duration)
 
-	if allowpre {
-		u.Path = "latest.txt"
-	} else {
-		u.Path = "stable.txt"
+// TLSClientConfig, when set, is used for the HTTPS connections the package level Latest* functions
+// make. It can be used to supply custom root CAs, client certificates or to disable certificate verification.
+//
+// Deprecated: Use NewClient with WithTLSClientConfig. Changing the variable while package level
+// functions are running is a data race.
+var TLSClientConfig *tls.Config
+
+var offline int32
+
+// SetOffline enables or disables offline mode for the package level functions. In offline mode,
+// functions that would need to access the network return ErrOffline.
+func SetOffline(enabled bool) {
+	var i int32
+	if enabled {
+		i = 1
 	}
+	atomic.StoreInt32(&offline, i)
+}
 
-	v, err := httpGet(u.String())
-	if err != nil {
-		return nil, err
+// IsOffline returns true if offline mode has been enabled using SetOffline or the K0S_VERSION_OFFLINE
+// environment variable.
+func IsOffline() bool {
+	if atomic.LoadInt32(&offline) == 1 {
+		return true
 	}
+	enabled, err := strconv.ParseBool(os.Getenv(OfflineEnv))
+	return err == nil && enabled
+}
 
-	return NewVersion(v)
+// LatestByPrerelease returns the latest released k0s version, if preok is true, prereleases are also accepted.
+func LatestByPrerelease(allowpre bool) (*Version, error) {
+	return LatestByPrereleaseContext(context.Background(), allowpre)
+}
+
+// LatestByPrereleaseContext is like LatestByPrerelease but accepts a context for cancellation.
+func LatestByPrereleaseContext(ctx context.Context, allowpre bool) (*Version, error) {
+	return DefaultClient().LatestByPrerelease(ctx, allowpre)
 }
 
 // LatestStable returns the semantically sorted latest non-prerelease version from the online repository
@@ -37,37 +70,57 @@ func LatestStable() (*Version, error) {
 	return LatestByPrerelease(false)
 }
 
+// LatestStableContext is like LatestStable but accepts a context for cancellation.
+func LatestStableContext(ctx context.Context) (*Version, error) {
+	return LatestByPrereleaseContext(ctx, false)
+}
+
 // LatestVersion returns the semantically sorted latest version even if it is a prerelease from the online repository
 func Latest() (*Version, error) {
 	return LatestByPrerelease(true)
 }
 
-func httpGet(u string) (string, error) {
-	client := &http.Client{
-		Timeout: Timeout,
-	}
+// LatestContext is like Latest but accepts a context for cancellation.
+func LatestContext(ctx context.Context) (*Version, error) {
+	return LatestByPrereleaseContext(ctx, true)
+}
 
-	resp, err := client.Get(u)
-	if err != nil {
-		return "", fmt.Errorf("http request to %s failed: %w", u, err)
-	}
+// Channels returns the available release channels and their current versions. See Client.Channels.
+func Channels(ctx context.Context) (map[string]*Version, error) {
+	return DefaultClient().Channels(ctx)
+}
 
-	if resp.Body == nil {
-		return "", fmt.Errorf("http request to %s failed: nil body", u)
-	}
+// ReleaseList returns the published k0s releases with their publication dates. See
+// Client.ReleaseList.
+func ReleaseList(ctx context.Context) ([]Release, error) {
+	return DefaultClient().ReleaseList(ctx)
+}
 
-	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("http request to %s failed: backend returned %d", u, resp.StatusCode)
-	}
+// EachRelease calls fn with each published k0s release as the release list is fetched. See
+// Client.EachRelease.
+func EachRelease(ctx context.Context, fn func(Release) error) error {
+	return DefaultClient().EachRelease(ctx, fn)
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("http request to %s failed: %w when reading body", u, err)
-	}
+// Releases returns the published k0s releases. See Client.Releases.
+func Releases(ctx context.Context) (Collection, error) {
+	return DefaultClient().Releases(ctx)
+}
 
-	if err := resp.Body.Close(); err != nil {
-		return "", fmt.Errorf("http request to %s failed: %w when closing body", u, err)
-	}
+// RateLimit returns the GitHub API rate limit status reported by the last API response received by
+// the package level functions. See Client.RateLimit.
+func RateLimit() (RateLimitStatus, bool) {
+	return DefaultClient().RateLimit()
+}
+
+// ResolveChannel returns the current version of a release channel such as "stable-1.28". See
+// Client.ResolveChannel.
+func ResolveChannel(ctx context.Context, name string) (*Version, error) {
+	return DefaultClient().ResolveChannel(ctx, name)
+}
 
-	return strings.TrimSpace(string(body)), nil
+// RecommendPatch returns the newest stable release within the release line of the current version.
+// See Client.RecommendPatch.
+func RecommendPatch(ctx context.Context, current *Version) (*Version, error) {
+	return DefaultClient().RecommendPatch(ctx, current)
 }
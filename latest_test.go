@@ -1,8 +1,12 @@
 package version_test
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/k0sproject/version"
 )
@@ -12,3 +16,47 @@ func TestLatestByPrerelease(t *testing.T) {
 	NoError(t, err)
 	True(t, regexp.MustCompile(`^v\d+\.\d+\.\d+\+k0s\.\d+$`).MatchString(r.String()))
 }
+
+func TestLatestByPrereleaseContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := version.LatestByPrereleaseContext(ctx, false)
+	Error(t, err)
+	True(t, errors.Is(err, context.Canceled))
+}
+
+func TestRequestObserver(t *testing.T) {
+	var called bool
+	version.RequestObserver = func(req *http.Request, resp *http.Response, err error, _ time.Duration) {
+		called = true
+		Equal(t, "https://docs.k0sproject.io/latest.txt", req.URL.String())
+		True(t, resp == nil)
+		Error(t, err)
+	}
+	defer func() { version.RequestObserver = nil }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := version.LatestContext(ctx)
+	Error(t, err)
+	True(t, called)
+}
+
+func TestOffline(t *testing.T) {
+	t.Run("SetOffline", func(t *testing.T) {
+		version.SetOffline(true)
+		defer version.SetOffline(false)
+		True(t, version.IsOffline())
+		_, err := version.LatestStable()
+		True(t, errors.Is(err, version.ErrOffline))
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv(version.OfflineEnv, "1")
+		True(t, version.IsOffline())
+		_, err := version.Latest()
+		True(t, errors.Is(err, version.ErrOffline))
+	})
+
+	False(t, version.IsOffline())
+}
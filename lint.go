@@ -0,0 +1,69 @@
+package version
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Diagnostic is a problem found by LintList on a line of a version list.
+type Diagnostic struct {
+	// Line is the 1-based line number of the offending entry.
+	Line int
+	// Value is the entry as it appears on the line, without surrounding whitespace.
+	Value string
+	// Message describes the problem.
+	Message string
+	// Err is the parse error for entries that are not valid versions and nil for other problems.
+	Err error
+}
+
+// String returns the diagnostic in the form "line N: message".
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("line %d: %s", d.Line, d.Message)
+}
+
+// LintList reads a list of versions, one per line, and reports invalid entries, duplicates and
+// entries that are not in ascending order. Empty lines and lines starting with # are ignored.
+func LintList(r io.Reader) []Diagnostic {
+	var (
+		diags    []Diagnostic
+		prev     *Version
+		prevLine int
+		lineNo   int
+	)
+	seen := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		v, err := NewVersion(line)
+		if err != nil {
+			diags = append(diags, Diagnostic{Line: lineNo, Value: line, Message: err.Error(), Err: err})
+			continue
+		}
+
+		key := strings.TrimPrefix(v.String(), "v")
+		if first, ok := seen[key]; ok {
+			diags = append(diags, Diagnostic{Line: lineNo, Value: line, Message: fmt.Sprintf("duplicate of line %d", first)})
+			continue
+		}
+		seen[key] = lineNo
+
+		if prev != nil && v.LessThan(prev) {
+			diags = append(diags, Diagnostic{Line: lineNo, Value: line, Message: fmt.Sprintf("%s is out of order: lower than %s on line %d", line, prev, prevLine)})
+		}
+		prev, prevLine = v, lineNo
+	}
+	if err := scanner.Err(); err != nil {
+		diags = append(diags, Diagnostic{Line: lineNo + 1, Message: fmt.Sprintf("read failed: %v", err)})
+	}
+
+	return diags
+}
@@ -0,0 +1,42 @@
+package version_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/k0sproject/version"
+)
+
+func TestLintList(t *testing.T) {
+	t.Run("clean", func(t *testing.T) {
+		input := "# mirrored versions\nv1.28.6+k0s.0\n\nv1.29.0+k0s.0\nv1.29.2+k0s.0\n"
+		Equal(t, 0, len(version.LintList(strings.NewReader(input))))
+	})
+
+	t.Run("problems", func(t *testing.T) {
+		input := strings.Join([]string{
+			"v1.28.6+k0s.0",
+			"v1.29.2+k0s.0",
+			"not-a-version",
+			"1.29.2+k0s.0",
+			"v1.29.0+k0s.0",
+			"v1.30.0+k0s.0",
+		}, "\n")
+		diags := version.LintList(strings.NewReader(input))
+		Equal(t, 3, len(diags))
+
+		Equal(t, 3, diags[0].Line)
+		Equal(t, "not-a-version", diags[0].Value)
+		True(t, strings.Contains(diags[0].Message, "invalid version"))
+		True(t, errors.Is(diags[0].Err, version.ErrInvalidVersion))
+		NoError(t, diags[1].Err)
+
+		Equal(t, 4, diags[1].Line)
+		Equal(t, "line 4: duplicate of line 2", diags[1].String())
+
+		Equal(t, 5, diags[2].Line)
+		True(t, strings.Contains(diags[2].Message, "out of order"))
+		True(t, strings.Contains(diags[2].Message, "line 2"))
+	})
+}
@@ -0,0 +1,118 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MajorMinor is a release line consisting of the major and minor version numbers (eg 1.29).
+type MajorMinor struct {
+	Major int
+	Minor int
+}
+
+// ParseMajorMinor parses a release line from a string like "1.29" or "v1.29".
+func ParseMajorMinor(s string) (MajorMinor, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	if len(parts) != 2 {
+		return MajorMinor{}, fmt.Errorf("%w: major.minor '%s': expected two segments", ErrInvalidVersion, s)
+	}
+	var segments [2]int
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return MajorMinor{}, wrapCause(ErrInvalidVersion, err, "%s: major.minor '%s': parsing segment '%s': %v", ErrInvalidVersion, s, part, err)
+		}
+		segments[i] = int(n)
+	}
+	return MajorMinor{Major: segments[0], Minor: segments[1]}, nil
+}
+
+// MustParseMajorMinor is like ParseMajorMinor but panics if the string cannot be parsed.
+func MustParseMajorMinor(s string) MajorMinor {
+	mm, err := ParseMajorMinor(s)
+	if err != nil {
+		panic("github.com/k0sproject/version: ParseMajorMinor: " + err.Error())
+	}
+	return mm
+}
+
+// String returns the release line as a string without the v prefix (eg 1.29)
+func (m MajorMinor) String() string {
+	return strconv.Itoa(m.Major) + "." + strconv.Itoa(m.Minor)
+}
+
+// NextMinor returns the next minor release line (eg 1.30 from 1.29)
+func (m MajorMinor) NextMinor() MajorMinor {
+	return MajorMinor{Major: m.Major, Minor: m.Minor + 1}
+}
+
+// NextMajor returns the first release line of the next major version (eg 2.0 from 1.29)
+func (m MajorMinor) NextMajor() MajorMinor {
+	return MajorMinor{Major: m.Major + 1}
+}
+
+// PrevMinor returns the previous minor release line (eg 1.28 from 1.29) and true. At minor 0 the
+// last minor of the previous major can't be known, so it returns the unchanged value and false.
+func (m MajorMinor) PrevMinor() (MajorMinor, bool) {
+	if m.Minor == 0 {
+		return m, false
+	}
+	return MajorMinor{Major: m.Major, Minor: m.Minor - 1}, true
+}
+
+// Constraint returns a constraint that matches all versions of the release line, including
+// prereleases (eg ">= 1.29.0-0, < 1.30.0-0" for 1.29).
+func (m MajorMinor) Constraint() Constraints {
+	next := m.NextMinor()
+	return MustConstraint(fmt.Sprintf(">= %d.%d.0-0, < %d.%d.0-0", m.Major, m.Minor, next.Major, next.Minor))
+}
+
+// MajorMinor returns the release line of the version (eg 1.29 from v1.29.2+k0s.0)
+func (v *Version) MajorMinor() MajorMinor {
+	return MajorMinor{Major: v.Major(), Minor: v.Minor()}
+}
+
+// GroupByMajorMinor returns the versions of the collection grouped by their release line. The order
+// of the versions within a group follows the collection. Nil entries are skipped.
+func (c Collection) GroupByMajorMinor() map[MajorMinor]Collection {
+	groups := make(map[MajorMinor]Collection)
+	for _, v := range c {
+		if v == nil {
+			continue
+		}
+		mm := v.MajorMinor()
+		groups[mm] = append(groups[mm], v)
+	}
+	return groups
+}
+
+// MarshalText implements the encoding.TextMarshaler interface (used as fallback by encoding/json and yaml.v3).
+func (m MajorMinor) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface (used as fallback by encoding/json and yaml.v3).
+func (m *MajorMinor) UnmarshalText(text []byte) error {
+	mm, err := ParseMajorMinor(string(text))
+	if err != nil {
+		return err
+	}
+	*m = mm
+	return nil
+}
+
+// MarshalYAML implements the yaml.v2 Marshaler interface.
+func (m MajorMinor) MarshalYAML() (interface{}, error) {
+	return m.String(), nil
+}
+
+// UnmarshalYAML implements the yaml.v2 Unmarshaler interface.
+func (m *MajorMinor) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var text string
+	if err := unmarshal(&text); err != nil {
+		return err
+	}
+	return m.UnmarshalText([]byte(text))
+}
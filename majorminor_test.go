@@ -0,0 +1,102 @@
+package version_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/k0sproject/version"
+)
+
+func TestParseMajorMinor(t *testing.T) {
+	mm, err := version.ParseMajorMinor("1.29")
+	NoError(t, err)
+	Equal(t, version.MajorMinor{Major: 1, Minor: 29}, mm)
+	Equal(t, "1.29", mm.String())
+
+	mm, err = version.ParseMajorMinor("v1.29")
+	NoError(t, err)
+	Equal(t, version.MajorMinor{Major: 1, Minor: 29}, mm)
+
+	for _, invalid := range []string{"", "1", "1.29.0", "1.x", "v", "1.-1", "a.b"} {
+		_, err := version.ParseMajorMinor(invalid)
+		Error(t, err)
+	}
+
+	Equal(t, version.MajorMinor{Major: 1, Minor: 29}, version.MustParse("v1.29.2+k0s.0").MajorMinor())
+}
+
+func TestMajorMinorMarshalling(t *testing.T) {
+	type config struct {
+		SupportedSince version.MajorMinor `json:"supportedSince"`
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(config{SupportedSince: version.MajorMinor{Major: 1, Minor: 25}})
+		NoError(t, err)
+		Equal(t, `{"supportedSince":"1.25"}`, string(data))
+
+		var c config
+		NoError(t, json.Unmarshal([]byte(`{"supportedSince":"v1.26"}`), &c))
+		Equal(t, version.MajorMinor{Major: 1, Minor: 26}, c.SupportedSince)
+
+		Error(t, json.Unmarshal([]byte(`{"supportedSince":"1.26.1"}`), &c))
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		yamlData, err := version.MajorMinor{Major: 1, Minor: 25}.MarshalYAML()
+		NoError(t, err)
+		Equal(t, "1.25", yamlData)
+
+		var mm version.MajorMinor
+		err = mm.UnmarshalYAML(func(i interface{}) error {
+			*(i.(*string)) = "1.27"
+			return nil
+		})
+		NoError(t, err)
+		Equal(t, version.MajorMinor{Major: 1, Minor: 27}, mm)
+
+		err = mm.UnmarshalYAML(func(i interface{}) error {
+			return errors.New("forced error")
+		})
+		Error(t, err)
+	})
+}
+
+func TestMajorMinorNextPrev(t *testing.T) {
+	mm := version.MustParseMajorMinor("1.29")
+	Equal(t, "1.30", mm.NextMinor().String())
+	Equal(t, "2.0", mm.NextMajor().String())
+
+	prev, ok := mm.PrevMinor()
+	True(t, ok)
+	Equal(t, "1.28", prev.String())
+
+	prev, ok = version.MustParseMajorMinor("2.0").PrevMinor()
+	False(t, ok)
+	Equal(t, "2.0", prev.String())
+}
+
+func TestMajorMinorConstraint(t *testing.T) {
+	c := version.MustParseMajorMinor("1.29").Constraint()
+	Equal(t, ">= 1.29.0-0, < 1.30.0-0", c.String())
+
+	for _, v := range []string{"1.29.0", "1.29.0-rc.1", "1.29.2+k0s.0", "1.29.10-beta.1+k0s.0"} {
+		True(t, version.MustParse(v).Satisfies(c))
+	}
+	for _, v := range []string{"1.28.9", "1.30.0", "1.30.0-rc.1", "2.29.0"} {
+		False(t, version.MustParse(v).Satisfies(c))
+	}
+}
+
+func TestGroupByMajorMinor(t *testing.T) {
+	c, err := version.NewCollection("v1.29.2+k0s.0", "v1.28.1+k0s.0", "v1.29.0+k0s.0", "v2.0.0")
+	NoError(t, err)
+	c = append(c, nil)
+
+	groups := c.GroupByMajorMinor()
+	Equal(t, 3, len(groups))
+	Equal(t, "v1.29.2+k0s.0, v1.29.0+k0s.0", groups[version.MustParseMajorMinor("1.29")].String())
+	Equal(t, "v1.28.1+k0s.0", groups[version.MustParseMajorMinor("1.28")].String())
+	Equal(t, "v2.0.0", groups[version.MustParseMajorMinor("2.0")].String())
+}
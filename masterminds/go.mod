@@ -0,0 +1,8 @@
+module github.com/k0sproject/version/masterminds
+
+go 1.18
+
+require (
+	github.com/Masterminds/semver/v3 v3.2.1
+	github.com/k0sproject/version v0.6.0
+)
@@ -0,0 +1,61 @@
+// Package masterminds converts between k0s versions and github.com/Masterminds/semver/v3 versions.
+// It is a separate module so that the version package itself stays free of dependencies. It only
+// uses the parts of the version package that are available in tagged releases, so that it can be
+// used with the released version package.
+package masterminds
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/k0sproject/version"
+)
+
+// ErrInvalidVersion is returned when a nil or empty version is converted.
+var ErrInvalidVersion = errors.New("invalid version")
+
+// ToSemver converts a k0s version into a Masterminds semver version. The k0s build number and any
+// other build metadata become the semver metadata (eg "k0s.1" from v1.29.2+k0s.1).
+func ToSemver(v *version.Version) (*semver.Version, error) {
+	if v.IsZero() {
+		return nil, fmt.Errorf("%w: empty version", ErrInvalidVersion)
+	}
+
+	var segments [3]uint64
+	for i, s := range v.Segments() {
+		if i < len(segments) {
+			segments[i] = uint64(s)
+		}
+	}
+
+	var meta string
+	if n, ok := v.K0s(); ok {
+		meta = "k0s." + strconv.Itoa(n)
+		if v.Metadata() != "" {
+			meta += "."
+		}
+	}
+	meta += v.Metadata()
+
+	return semver.New(segments[0], segments[1], segments[2], v.Prerelease(), meta), nil
+}
+
+// FromSemver converts a Masterminds semver version into a k0s version. The result is validated like
+// any other k0s version, so prerelease or metadata identifiers the version package does not accept
+// return the parse error from version.NewVersion.
+func FromSemver(sv *semver.Version) (*version.Version, error) {
+	if sv == nil {
+		return nil, fmt.Errorf("%w: nil semver version", ErrInvalidVersion)
+	}
+
+	s := fmt.Sprintf("%d.%d.%d", sv.Major(), sv.Minor(), sv.Patch())
+	if sv.Prerelease() != "" {
+		s += "-" + sv.Prerelease()
+	}
+	if sv.Metadata() != "" {
+		s += "+" + sv.Metadata()
+	}
+	return version.NewVersion(s)
+}
@@ -0,0 +1,80 @@
+package masterminds_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/k0sproject/version"
+	"github.com/k0sproject/version/masterminds"
+)
+
+func TestToSemver(t *testing.T) {
+	testCases := []struct {
+		in       string
+		expected string
+	}{
+		{"v1.29.2+k0s.0", "1.29.2+k0s.0"},
+		{"v1.30.0-rc.1+k0s.1", "1.30.0-rc.1+k0s.1"},
+		{"v1.29.2+k0s.1.abc", "1.29.2+k0s.1.abc"},
+		{"v1.29", "1.29.0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			sv, err := masterminds.ToSemver(version.MustParse(tc.in))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sv.String() != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, sv.String())
+			}
+		})
+	}
+
+	if _, err := masterminds.ToSemver(nil); !errors.Is(err, masterminds.ErrInvalidVersion) {
+		t.Fatalf("expected ErrInvalidVersion for a nil version, got %v", err)
+	}
+}
+
+func TestFromSemver(t *testing.T) {
+	testCases := []struct {
+		in       string
+		expected string
+	}{
+		{"1.29.2+k0s.0", "v1.29.2+k0s.0"},
+		{"v1.30.0-rc.1+k0s.1", "v1.30.0-rc.1+k0s.1"},
+		{"1.29", "v1.29.0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			v, err := masterminds.FromSemver(semver.MustParse(tc.in))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if v.String() != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, v.String())
+			}
+		})
+	}
+
+	if _, err := masterminds.FromSemver(nil); !errors.Is(err, masterminds.ErrInvalidVersion) {
+		t.Fatalf("expected ErrInvalidVersion for a nil version, got %v", err)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, s := range []string{"v1.29.2+k0s.0", "v1.30.0-rc.1+k0s.1", "v1.28.0-beta.2"} {
+		v := version.MustParse(s)
+		sv, err := masterminds.ToSemver(v)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		back, err := masterminds.FromSemver(sv)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !back.Equal(v) || back.String() != v.String() {
+			t.Fatalf("round trip of %s produced %s", v, back)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package version
+
+// VersionMatcher is implemented by types that can tell whether a version matches a condition.
+// Constraints implements VersionMatcher.
+type VersionMatcher interface {
+	Check(v *Version) bool
+}
+
+// VersionComparer is implemented by types that can be compared to a version, returning
+// 0 if equal, 1 if greater and -1 if lower. *Version implements VersionComparer.
+type VersionComparer interface {
+	Compare(b *Version) int
+}
+
+// MatcherFunc is an adapter to allow the use of ordinary functions as version matchers.
+type MatcherFunc func(v *Version) bool
+
+// Check calls f(v).
+func (f MatcherFunc) Check(v *Version) bool {
+	return f(v)
+}
+
+// AtLeast returns a matcher that matches versions greater than or equal to b. Unlike
+// constraints, matchers do not treat prereleases specially.
+func AtLeast(b VersionComparer) VersionMatcher {
+	return MatcherFunc(func(v *Version) bool {
+		return v != nil && b.Compare(v) <= 0
+	})
+}
+
+// AtMost returns a matcher that matches versions lower than or equal to b.
+func AtMost(b VersionComparer) VersionMatcher {
+	return MatcherFunc(func(v *Version) bool {
+		return v != nil && b.Compare(v) >= 0
+	})
+}
+
+// Between returns a matcher that matches versions between min and max, inclusive.
+func Between(min, max VersionComparer) VersionMatcher {
+	return All(AtLeast(min), AtMost(max))
+}
+
+// Any returns a matcher that matches versions matched by at least one of the supplied matchers.
+func Any(matchers ...VersionMatcher) VersionMatcher {
+	return MatcherFunc(func(v *Version) bool {
+		for _, m := range matchers {
+			if m.Check(v) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// All returns a matcher that matches versions matched by all of the supplied matchers.
+func All(matchers ...VersionMatcher) VersionMatcher {
+	return MatcherFunc(func(v *Version) bool {
+		for _, m := range matchers {
+			if !m.Check(v) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Not returns a matcher that matches versions not matched by the supplied matcher.
+func Not(m VersionMatcher) VersionMatcher {
+	return MatcherFunc(func(v *Version) bool {
+		return !m.Check(v)
+	})
+}
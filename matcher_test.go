@@ -0,0 +1,48 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/k0sproject/version"
+)
+
+func TestMatchers(t *testing.T) {
+	v := version.MustParse("1.23.3+k0s.1")
+
+	True(t, v.Is(version.AtLeast(version.MustParse("1.23.3"))))
+	True(t, v.Is(version.AtLeast(version.MustParse("1.23.3+k0s.1"))))
+	False(t, v.Is(version.AtLeast(version.MustParse("1.23.3+k0s.2"))))
+
+	True(t, v.Is(version.AtMost(version.MustParse("1.23.3+k0s.1"))))
+	True(t, v.Is(version.AtMost(version.MustParse("1.24.0"))))
+	False(t, v.Is(version.AtMost(version.MustParse("1.23.2"))))
+
+	True(t, v.Is(version.Between(version.MustParse("1.23.0"), version.MustParse("1.24.0"))))
+	False(t, v.Is(version.Between(version.MustParse("1.24.0"), version.MustParse("1.25.0"))))
+
+	True(t, v.Is(version.Any(version.AtMost(version.MustParse("1.0.0")), version.AtLeast(version.MustParse("1.23.0")))))
+	False(t, v.Is(version.Any()))
+	False(t, v.Is(version.All(version.AtMost(version.MustParse("1.0.0")), version.AtLeast(version.MustParse("1.23.0")))))
+	True(t, v.Is(version.All()))
+
+	True(t, v.Is(version.MustConstraint(">= 1.23")))
+	False(t, version.AtLeast(version.MustParse("1.0.0")).Check(nil))
+}
+
+func TestMatcherCombinators(t *testing.T) {
+	// 1.28.x or >= 1.29.2, but not prereleases
+	m := version.All(
+		version.Any(
+			version.MustParseMajorMinor("1.28").Constraint(),
+			version.AtLeast(version.MustParse("1.29.2")),
+		),
+		version.Not(version.MatcherFunc((*version.Version).IsPrerelease)),
+	)
+
+	for _, v := range []string{"1.28.0", "1.28.5+k0s.1", "1.29.2+k0s.0", "1.30.0"} {
+		True(t, version.MustParse(v).Is(m))
+	}
+	for _, v := range []string{"1.27.9", "1.28.1-rc.1", "1.29.1", "1.30.0-rc.1"} {
+		False(t, version.MustParse(v).Is(m))
+	}
+}
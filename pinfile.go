@@ -0,0 +1,96 @@
+package version
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// PinFileName is the conventional name of a project-local version pin file.
+const PinFileName = ".k0s-version"
+
+// ReadPinFile reads a version pin file. The file must contain a single version or a channel name
+// (eg "stable" or "stable-1.29"), empty lines and lines starting with # are ignored. Channel names
+// are resolved online with ResolveChannel using the package level configuration.
+func ReadPinFile(path string) (*Version, error) {
+	return ReadPinFileContext(context.Background(), path)
+}
+
+// ReadPinFileContext is like ReadPinFile but accepts a context for cancellation.
+func ReadPinFileContext(ctx context.Context, path string) (*Version, error) {
+	return DefaultClient().ReadPinFile(ctx, path)
+}
+
+// ReadPinFile reads a version pin file, resolving channel names using the client. See the
+// package level ReadPinFile for the file format.
+func (c *Client) ReadPinFile(ctx context.Context, path string) (*Version, error) {
+	pin, err := readPin(path)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := NewVersion(pin)
+	if err == nil {
+		return v, nil
+	}
+	if !channelNameRegex.MatchString(pin) {
+		return nil, fmt.Errorf("invalid pin '%s' in %s: %w", pin, path, err)
+	}
+
+	v, err = c.ResolveChannel(ctx, pin)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("invalid pin '%s' in %s: not a version or a known channel: %w", pin, path, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolve channel '%s' from %s: %w", pin, path, err)
+	}
+	return v, nil
+}
+
+func readPin(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("read pin file: %w", err)
+	}
+	defer f.Close()
+
+	var pin string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if pin != "" {
+			return "", fmt.Errorf("read pin file %s: more than one pin", path)
+		}
+		pin = line
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("read pin file %s: %w", path, err)
+	}
+	if pin == "" {
+		return "", fmt.Errorf("read pin file %s: no pin found", path)
+	}
+	return pin, nil
+}
+
+// WritePinFile writes a pin to a pin file, replacing any existing content. The pin is a version or
+// a channel name (eg "stable-1.29"), the same as what ReadPinFile accepts. Versions are written in
+// their canonical form and channel names are written as they are, to be resolved when the file is
+// read.
+func WritePinFile(path, pin string) error {
+	pin = strings.TrimSpace(pin)
+	if v, err := NewVersion(pin); err == nil {
+		pin = v.String()
+	} else if !channelNameRegex.MatchString(pin) {
+		return fmt.Errorf("write pin file %s: invalid pin '%s': not a version or a channel name: %w", path, pin, err)
+	}
+	if err := os.WriteFile(path, []byte(pin+"\n"), 0o644); err != nil {
+		return fmt.Errorf("write pin file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package version_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k0sproject/version"
+)
+
+func TestPinFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), version.PinFileName)
+
+	NoError(t, version.WritePinFile(path, "1.29.2+k0s.0"))
+	v, err := version.ReadPinFile(path)
+	NoError(t, err)
+	Equal(t, "v1.29.2+k0s.0", v.String())
+
+	NoError(t, os.WriteFile(path, []byte("# pinned for the demo\n\n1.28.1+k0s.0\n"), 0o644))
+	v, err = version.ReadPinFile(path)
+	NoError(t, err)
+	Equal(t, "v1.28.1+k0s.0", v.String())
+
+	NoError(t, os.WriteFile(path, []byte("1.28.1\n1.29.0\n"), 0o644))
+	_, err = version.ReadPinFile(path)
+	Error(t, err)
+
+	NoError(t, os.WriteFile(path, []byte("# nothing\n"), 0o644))
+	_, err = version.ReadPinFile(path)
+	Error(t, err)
+
+	_, err = version.ReadPinFile(filepath.Join(t.TempDir(), "missing"))
+	Error(t, err)
+
+	Error(t, version.WritePinFile(path, ""))
+	Error(t, version.WritePinFile(path, "stable 1.29"))
+}
+
+func TestPinFileChannel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), version.PinFileName)
+	NoError(t, version.WritePinFile(path, "stable"))
+	data, err := os.ReadFile(path)
+	NoError(t, err)
+	Equal(t, "stable\n", string(data))
+
+	server := newTestServer(t)
+	c := newTestClient(server)
+	v, err := c.ReadPinFile(context.Background(), path)
+	NoError(t, err)
+	Equal(t, "v1.29.2+k0s.0", v.String())
+
+	NoError(t, os.WriteFile(path, []byte("stable-1.20\n"), 0o644))
+	v, err = c.ReadPinFile(context.Background(), path)
+	NoError(t, err)
+	Equal(t, "v1.20.14+k0s.0", v.String())
+
+	NoError(t, os.WriteFile(path, []byte("stable-1.19\n"), 0o644))
+	_, err = c.ReadPinFile(context.Background(), path)
+	True(t, errors.Is(err, version.ErrNotFound))
+
+	version.SetOffline(true)
+	defer version.SetOffline(false)
+	NoError(t, os.WriteFile(path, []byte("stable-1.20\n"), 0o644))
+	_, err = version.ReadPinFile(path)
+	True(t, errors.Is(err, version.ErrOffline))
+
+	// content that can't be a channel name is reported as invalid without going online
+	NoError(t, os.WriteFile(path, []byte("v1.29.x\n"), 0o644))
+	_, err = version.ReadPinFile(path)
+	True(t, errors.Is(err, version.ErrInvalidVersion))
+	False(t, errors.Is(err, version.ErrOffline))
+}
@@ -0,0 +1,160 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Range is an interval of versions. A nil Min or Max means the range is unbounded in that direction.
+// Unlike constraints, ranges do not treat prereleases specially, versions are only compared by
+// precedence.
+type Range struct {
+	Min          *Version
+	Max          *Version
+	MinInclusive bool
+	MaxInclusive bool
+}
+
+// NewRangeFromConstraint returns the range of versions described by the constraints. Constraints
+// using the != operator can't be represented as a single range and return an error.
+func NewRangeFromConstraint(cs Constraints) (Range, error) {
+	r := Range{}
+	for _, c := range cs {
+		var cr Range
+		switch c.op {
+		case "=":
+			cr = Range{Min: c.b, Max: c.b, MinInclusive: true, MaxInclusive: true}
+		case ">":
+			cr = Range{Min: c.b}
+		case ">=":
+			cr = Range{Min: c.b, MinInclusive: true}
+		case "<":
+			cr = Range{Max: c.b}
+		case "<=":
+			cr = Range{Max: c.b, MaxInclusive: true}
+		default:
+			return Range{}, fmt.Errorf("%w: %s can't be represented as a range", ErrInvalidConstraint, c.String())
+		}
+		var ok bool
+		r, ok = r.Intersect(cr)
+		if !ok {
+			return Range{}, fmt.Errorf("%w: %s does not match any version", ErrNoVersions, cs.String())
+		}
+	}
+	return r, nil
+}
+
+// IsEmpty returns true if no version can be contained in the range.
+func (r Range) IsEmpty() bool {
+	if r.Min == nil || r.Max == nil {
+		return false
+	}
+	switch r.Min.Compare(r.Max) {
+	case 1:
+		return true
+	case 0:
+		return !r.MinInclusive || !r.MaxInclusive
+	}
+	return false
+}
+
+// Contains returns true if the version is within the range.
+func (r Range) Contains(v *Version) bool {
+	if v == nil {
+		return false
+	}
+	if r.Min != nil {
+		if c := v.Compare(r.Min); c < 0 || (c == 0 && !r.MinInclusive) {
+			return false
+		}
+	}
+	if r.Max != nil {
+		if c := v.Compare(r.Max); c > 0 || (c == 0 && !r.MaxInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// Check returns true if the version is within the range. It makes Range a VersionMatcher.
+func (r Range) Check(v *Version) bool {
+	return r.Contains(v)
+}
+
+// Intersect returns the range of versions contained in both ranges and true, or an empty
+// Range and false if the ranges don't overlap.
+func (r Range) Intersect(o Range) (Range, bool) {
+	result := Range{
+		Min:          r.Min,
+		MinInclusive: r.MinInclusive,
+		Max:          r.Max,
+		MaxInclusive: r.MaxInclusive,
+	}
+	if o.Min != nil {
+		switch {
+		case result.Min == nil:
+			result.Min, result.MinInclusive = o.Min, o.MinInclusive
+		case o.Min.Compare(result.Min) > 0:
+			result.Min, result.MinInclusive = o.Min, o.MinInclusive
+		case o.Min.Compare(result.Min) == 0:
+			result.MinInclusive = result.MinInclusive && o.MinInclusive
+		}
+	}
+	if o.Max != nil {
+		switch {
+		case result.Max == nil:
+			result.Max, result.MaxInclusive = o.Max, o.MaxInclusive
+		case o.Max.Compare(result.Max) < 0:
+			result.Max, result.MaxInclusive = o.Max, o.MaxInclusive
+		case o.Max.Compare(result.Max) == 0:
+			result.MaxInclusive = result.MaxInclusive && o.MaxInclusive
+		}
+	}
+	if result.IsEmpty() {
+		return Range{}, false
+	}
+	return result, true
+}
+
+// Overlaps returns true if there are versions that are contained in both ranges.
+func (r Range) Overlaps(o Range) bool {
+	_, ok := r.Intersect(o)
+	return ok
+}
+
+// String returns the range as a constraint string (eg ">= 1.2.3, < 1.3.0"). An unbounded range
+// returns an empty string.
+func (r Range) String() string {
+	var parts []string
+	if r.Min != nil && r.Max != nil && r.MinInclusive && r.MaxInclusive && r.Min.Equal(r.Max) {
+		return "= " + r.Min.String()
+	}
+	if r.Min != nil {
+		op := ">"
+		if r.MinInclusive {
+			op = ">="
+		}
+		parts = append(parts, op+" "+r.Min.String())
+	}
+	if r.Max != nil {
+		op := "<"
+		if r.MaxInclusive {
+			op = "<="
+		}
+		parts = append(parts, op+" "+r.Max.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Constraint returns the range as a constraint. An unbounded range returns an empty Constraints that
+// is satisfied by any version. Note that constraints without a prerelease bound don't accept
+// prerelease versions, while ranges do.
+func (r Range) Constraint() (Constraints, error) {
+	if r.IsEmpty() {
+		return Constraints{}, fmt.Errorf("%w: empty range can't be converted to a constraint", ErrNoVersions)
+	}
+	if r.Min == nil && r.Max == nil {
+		return Constraints{}, nil
+	}
+	return NewConstraint(r.String())
+}
@@ -0,0 +1,73 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/k0sproject/version"
+)
+
+func TestRangeContains(t *testing.T) {
+	r := version.Range{
+		Min:          version.MustParse("1.2.0"),
+		Max:          version.MustParse("1.3.0"),
+		MinInclusive: true,
+	}
+	True(t, r.Contains(version.MustParse("1.2.0")))
+	True(t, r.Contains(version.MustParse("1.2.9+k0s.1")))
+	True(t, r.Contains(version.MustParse("1.3.0-rc.1")))
+	False(t, r.Contains(version.MustParse("1.3.0")))
+	False(t, r.Contains(version.MustParse("1.1.9")))
+	False(t, r.Contains(nil))
+
+	True(t, version.Range{}.Contains(version.MustParse("0.0.1")))
+	True(t, version.MustParse("1.2.5").Is(r))
+}
+
+func TestRangeIntersect(t *testing.T) {
+	a := version.Range{Min: version.MustParse("1.2.0"), MinInclusive: true, Max: version.MustParse("1.4.0")}
+	b := version.Range{Min: version.MustParse("1.3.0"), Max: version.MustParse("1.5.0"), MaxInclusive: true}
+
+	i, ok := a.Intersect(b)
+	True(t, ok)
+	Equal(t, "> v1.3.0, < v1.4.0", i.String())
+	True(t, a.Overlaps(b))
+
+	c := version.Range{Min: version.MustParse("1.4.0"), MinInclusive: true}
+	False(t, a.Overlaps(c))
+	_, ok = a.Intersect(c)
+	False(t, ok)
+
+	d := version.Range{Max: version.MustParse("1.2.0"), MaxInclusive: true}
+	i, ok = a.Intersect(d)
+	True(t, ok)
+	Equal(t, "= v1.2.0", i.String())
+
+	i, ok = a.Intersect(version.Range{})
+	True(t, ok)
+	Equal(t, a, i)
+}
+
+func TestRangeConstraintConversion(t *testing.T) {
+	r, err := version.NewRangeFromConstraint(version.MustConstraint(">= 1.2, < 1.3, > 1.1"))
+	NoError(t, err)
+	Equal(t, ">= v1.2.0, < v1.3.0", r.String())
+
+	c, err := r.Constraint()
+	NoError(t, err)
+	True(t, c.Check(version.MustParse("1.2.3")))
+	False(t, c.Check(version.MustParse("1.3.0")))
+
+	r, err = version.NewRangeFromConstraint(version.MustConstraint("1.2.3"))
+	NoError(t, err)
+	Equal(t, "= v1.2.3", r.String())
+
+	_, err = version.NewRangeFromConstraint(version.MustConstraint("!= 1.2.3"))
+	Error(t, err)
+
+	_, err = version.NewRangeFromConstraint(version.MustConstraint(">= 1.3, < 1.2"))
+	Error(t, err)
+
+	c, err = version.Range{}.Constraint()
+	NoError(t, err)
+	True(t, c.Check(version.MustParse("1.2.3")))
+}
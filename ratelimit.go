@@ -0,0 +1,60 @@
+package version
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitStatus is the GitHub API rate limit status reported by an API response.
+type RateLimitStatus struct {
+	// Limit is the number of requests allowed in the current window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is the time when the current window ends and the remaining count is reset.
+	Reset time.Time
+}
+
+// rateLimitState holds the rate limit status of the last GitHub API response.
+type rateLimitState struct {
+	sync.Mutex
+	status RateLimitStatus
+	ok     bool
+}
+
+// defaultRateLimit is the rate limit state shared by the clients returned from DefaultClient.
+var defaultRateLimit = &rateLimitState{}
+
+// record stores the rate limit status from the response headers, if there is one.
+func (s *rateLimitState) record(h http.Header) (RateLimitStatus, bool) {
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return RateLimitStatus{}, false
+	}
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return RateLimitStatus{}, false
+	}
+	reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return RateLimitStatus{}, false
+	}
+	status := RateLimitStatus{Limit: limit, Remaining: remaining, Reset: time.Unix(reset, 0)}
+
+	s.Lock()
+	defer s.Unlock()
+	s.status = status
+	s.ok = true
+	return status, true
+}
+
+// RateLimit returns the GitHub API rate limit status reported by the last API response the client
+// received and true, or false if no API response with rate limit information has been received
+// yet. Batch tools can use it to schedule their requests.
+func (c *Client) RateLimit() (RateLimitStatus, bool) {
+	c.rateLimit.Lock()
+	defer c.rateLimit.Unlock()
+	return c.rateLimit.status, c.rateLimit.ok
+}
@@ -0,0 +1,57 @@
+package version_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/version"
+)
+
+func TestClientRateLimit(t *testing.T) {
+	c := newTestClient(newTestServer(t))
+	_, ok := c.RateLimit()
+	False(t, ok)
+
+	_, err := c.LatestStable(context.Background())
+	NoError(t, err)
+	_, ok = c.RateLimit()
+	False(t, ok)
+
+	_, err = c.Releases(context.Background())
+	NoError(t, err)
+	limit, ok := c.RateLimit()
+	True(t, ok)
+	Equal(t, 5000, limit.Limit)
+	Equal(t, 4998, limit.Remaining)
+	True(t, limit.Reset.Equal(time.Unix(1700000000, 0)))
+}
+
+func TestClientRateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		http.Error(w, "API rate limit exceeded", http.StatusForbidden)
+	}))
+	t.Cleanup(server.Close)
+
+	c := version.NewClient(version.WithAPIURL(server.URL), version.WithHTTPClient(server.Client()), version.WithToken(""))
+	_, err := c.Releases(context.Background())
+	True(t, errors.Is(err, version.ErrRateLimited))
+	True(t, strings.Contains(err.Error(), time.Unix(1700000000, 0).Format(time.RFC3339)))
+
+	limit, ok := c.RateLimit()
+	True(t, ok)
+	Equal(t, 0, limit.Remaining)
+
+	// responses from the base URL are not GitHub API responses
+	c = version.NewClient(version.WithBaseURL(server.URL), version.WithHTTPClient(server.Client()))
+	_, err = c.LatestStable(context.Background())
+	Error(t, err)
+	False(t, errors.Is(err, version.ErrRateLimited))
+}
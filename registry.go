@@ -0,0 +1,196 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// DefaultRegistryRepository is the container image repository of k0s on Docker Hub.
+const DefaultRegistryRepository = "docker.io/k0sproject/k0s"
+
+// registryTagRegex matches k0s image tags such as "v1.29.2-k0s.0" or "v1.30.0-rc.1-k0s.0". Image
+// tags can't contain "+", so the k0s build metadata is separated with a dash.
+var registryTagRegex = regexp.MustCompile(`^(v?\d+\.\d+\.\d+(?:-[0-9A-Za-z.]+)?)-(k0s\.\d+)$`)
+
+// registryChallengeRegex matches the parameters of a WWW-Authenticate Bearer challenge.
+var registryChallengeRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// NewCollectionFromRegistry returns a sorted collection of the k0s versions found in the tags of a
+// container image repository, such as DefaultRegistryRepository. See Client.NewCollectionFromRegistry.
+func NewCollectionFromRegistry(ctx context.Context, repository string) (Collection, error) {
+	return DefaultClient().NewCollectionFromRegistry(ctx, repository)
+}
+
+// NewCollectionFromRegistry returns a sorted collection of the k0s versions found in the tags of a
+// container image repository (eg docker.io/k0sproject/k0s or quay.io/k0sproject/k0s), for hosts
+// that can reach a registry but not the GitHub API. The tags are listed with the OCI distribution
+// API, using an anonymous token when the registry asks for one. Tags like "v1.29.2-k0s.0" are read
+// as v1.29.2+k0s.0, and tags that aren't k0s versions, such as "latest", are ignored.
+func (c *Client) NewCollectionFromRegistry(ctx context.Context, repository string) (Collection, error) {
+	if c.offline {
+		return nil, ErrOffline
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	host, name := parseRepository(repository)
+	u := fmt.Sprintf("https://%s/v2/%s/tags/list", host, name)
+	var token string
+	seen := make(map[string]struct{})
+	collection := Collection{}
+	for u != "" {
+		resp, err := c.registryGet(ctx, u, &token)
+		if err != nil {
+			return nil, err
+		}
+		var list struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&list)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s failed: %w", u, err)
+		}
+		for _, tag := range list.Tags {
+			v, ok := versionFromTag(tag)
+			if !ok {
+				continue
+			}
+			if _, ok := seen[v.String()]; ok {
+				continue
+			}
+			seen[v.String()] = struct{}{}
+			collection = append(collection, v)
+		}
+
+		next := linkURL(resp.Header, "next")
+		if next == "" {
+			break
+		}
+		nu, err := resp.Request.URL.Parse(next)
+		if err != nil {
+			return nil, fmt.Errorf("http request to %s failed: invalid next link: %w", u, err)
+		}
+		u = nu.String()
+	}
+
+	return collection.Sorted(), nil
+}
+
+// registryGet makes a GET request to the registry. When the registry responds with a Bearer
+// challenge, an anonymous token is requested from the realm of the challenge, stored in token for
+// the following requests, and the request is retried with it.
+func (c *Client) registryGet(ctx context.Context, u string, token *string) (*http.Response, error) {
+	for retried := false; ; retried = true {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("http request to %s failed: %w", u, err)
+		}
+		if *token != "" {
+			req.Header.Set("Authorization", "Bearer "+*token)
+		}
+		resp, err := c.send(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnauthorized && !retried {
+			_ = resp.Body.Close()
+			*token, err = c.registryToken(ctx, resp.Header.Get("WWW-Authenticate"))
+			if err != nil {
+				return nil, fmt.Errorf("http request to %s failed: %w", u, err)
+			}
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("http request to %s failed: backend returned %d", u, resp.StatusCode)
+		}
+		return resp, nil
+	}
+}
+
+// registryToken requests an anonymous token for the WWW-Authenticate Bearer challenge.
+func (c *Client) registryToken(ctx context.Context, challenge string) (string, error) {
+	if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		return "", fmt.Errorf("unsupported registry authentication challenge %q", challenge)
+	}
+	params := make(map[string]string)
+	for _, match := range registryChallengeRegex.FindAllStringSubmatch(challenge, -1) {
+		params[match[1]] = match[2]
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme == "" {
+		return "", fmt.Errorf("invalid registry token realm %q", params["realm"])
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("registry token request to %s failed: %w", realm, err)
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return "", fmt.Errorf("registry token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry token request to %s failed: backend returned %d", realm, resp.StatusCode)
+	}
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding %s failed: %w", realm, err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", fmt.Errorf("registry token request to %s failed: no token in response", realm)
+}
+
+// parseRepository splits a repository reference into the registry host and the repository name.
+// References without a registry host and ones on docker.io refer to Docker Hub.
+func parseRepository(repository string) (string, string) {
+	host, name := "docker.io", repository
+	if i := strings.Index(repository, "/"); i >= 0 {
+		first := repository[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			host, name = first, repository[i+1:]
+		}
+	}
+	if host == "docker.io" || host == "index.docker.io" {
+		host = "registry-1.docker.io"
+		if !strings.Contains(name, "/") {
+			name = "library/" + name
+		}
+	}
+	return host, name
+}
+
+func versionFromTag(tag string) (*Version, bool) {
+	match := registryTagRegex.FindStringSubmatch(tag)
+	if match == nil {
+		return nil, false
+	}
+	v, err := NewVersion(match[1] + "+" + match[2])
+	if err != nil {
+		return nil, false
+	}
+	return v, true
+}
@@ -0,0 +1,59 @@
+package version_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k0sproject/version"
+)
+
+func TestNewCollectionFromRegistry(t *testing.T) {
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("scope") != "repository:k0sproject/k0s:pull" || r.URL.Query().Get("service") != "test-registry" {
+			http.Error(w, "bad scope", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "anonymous"})
+	})
+	mux.HandleFunc("/v2/k0sproject/k0s/tags/list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer anonymous" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test-registry",scope="repository:k0sproject/k0s:pull"`, server.URL))
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		tags := []string{"latest", "v1.28.6-k0s.0", "v1.29.2-k0s.0", "v1.29.2-k0s.0-amd64"}
+		if r.URL.Query().Get("last") == "" {
+			w.Header().Set("Link", `</v2/k0sproject/k0s/tags/list?last=v1.29.2-k0s.0-amd64&n=4>; rel="next"`)
+		} else {
+			tags = []string{"v1.29.2-k0s.0", "v1.30.0-rc.1-k0s.0", "v1.27.1-k0s.1", "1.2.3"}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": "k0sproject/k0s", "tags": tags})
+	})
+	server = httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	c := version.NewClient(version.WithHTTPClient(server.Client()))
+	repository := strings.TrimPrefix(server.URL, "https://") + "/k0sproject/k0s"
+	collection, err := c.NewCollectionFromRegistry(context.Background(), repository)
+	NoError(t, err)
+	Equal(t, 4, len(collection))
+	Equal(t, "v1.27.1+k0s.1", collection[0].String())
+	Equal(t, "v1.28.6+k0s.0", collection[1].String())
+	Equal(t, "v1.29.2+k0s.0", collection[2].String())
+	Equal(t, "v1.30.0-rc.1+k0s.0", collection[3].String())
+
+	_, err = c.NewCollectionFromRegistry(context.Background(), strings.TrimPrefix(server.URL, "https://")+"/k0sproject/missing")
+	Error(t, err)
+
+	c = version.NewClient(version.WithOffline(true))
+	_, err = c.NewCollectionFromRegistry(context.Background(), version.DefaultRegistryRepository)
+	True(t, errors.Is(err, version.ErrOffline))
+}
@@ -0,0 +1,275 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// releasesPerPage is the page size used when listing releases from the GitHub API.
+const releasesPerPage = 100
+
+// releaseWorkers is the maximum number of release list pages fetched concurrently.
+const releaseWorkers = 4
+
+// Release is a published k0s release.
+type Release struct {
+	// Version is the version of the release.
+	Version *Version `json:"version"`
+	// Prerelease is true if the release is marked as a prerelease on GitHub.
+	Prerelease bool `json:"prerelease"`
+	// PublishedAt is the time the release was published.
+	PublishedAt time.Time `json:"published_at"`
+}
+
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// releaseCache holds the release list fetched by ReleaseList.
+type releaseCache struct {
+	sync.Mutex
+	list    []Release
+	fetched time.Time
+}
+
+// defaultReleases is the release list cache shared by the clients returned from DefaultClient, so
+// that repeated calls to the package level functions don't fetch the release list every time.
+var defaultReleases = &releaseCache{}
+
+// ReleaseList returns the published k0s releases with their publication dates and prerelease flags,
+// in the order the GitHub releases API lists them, newest first. Draft releases and tags that are
+// not valid versions are skipped. The list is fetched page by page and reused for the duration set
+// with WithCacheMaxAge, so callers such as ResolveChannel and RecommendPatch don't fetch it again on
+// every call. The returned slice is a copy that the caller may modify.
+func (c *Client) ReleaseList(ctx context.Context) ([]Release, error) {
+	if c.offline {
+		return nil, ErrOffline
+	}
+
+	c.releases.Lock()
+	defer c.releases.Unlock()
+	if c.releases.list == nil || c.maxAge <= 0 || time.Since(c.releases.fetched) >= c.maxAge {
+		list, err := c.fetchReleases(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.releases.list = list
+		c.releases.fetched = time.Now()
+	}
+	return append(make([]Release, 0, len(c.releases.list)), c.releases.list...), nil
+}
+
+// EachRelease calls fn with each published k0s release in the order of ReleaseList, as the pages of
+// the release list arrive, so that callers can show results before the whole list has been fetched.
+// If fn returns an error, no more releases are fetched and EachRelease returns the error. When the
+// cached release list is fresh, fn is called with its releases without making any requests, and a
+// walk that reaches the end of the list fills the cache.
+func (c *Client) EachRelease(ctx context.Context, fn func(Release) error) error {
+	if c.offline {
+		return ErrOffline
+	}
+
+	c.releases.Lock()
+	var cached []Release
+	if c.releases.list != nil && c.maxAge > 0 && time.Since(c.releases.fetched) < c.maxAge {
+		cached = append(make([]Release, 0, len(c.releases.list)), c.releases.list...)
+	}
+	c.releases.Unlock()
+	if cached != nil {
+		for _, r := range cached {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	list := []Release{}
+	err := c.walkReleases(ctx, func(page []Release) error {
+		for _, r := range page {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+		list = append(list, page...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.releases.Lock()
+	c.releases.list = list
+	c.releases.fetched = time.Now()
+	c.releases.Unlock()
+	return nil
+}
+
+// Releases returns the versions of the releases returned by ReleaseList.
+func (c *Client) Releases(ctx context.Context) (Collection, error) {
+	list, err := c.ReleaseList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	releases := make(Collection, len(list))
+	for i, r := range list {
+		releases[i] = r.Version
+	}
+	return releases, nil
+}
+
+func (c *Client) fetchReleases(ctx context.Context) ([]Release, error) {
+	releases := []Release{}
+	err := c.walkReleases(ctx, func(page []Release) error {
+		releases = append(releases, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+// releasePage is a page of the release list fetched by a walkReleases worker.
+type releasePage struct {
+	releases []Release
+	err      error
+}
+
+// walkReleases fetches the release list page by page and calls fn with each page in order. When the
+// first page has a Link header pointing to the last page, the rest of the pages are fetched by up to
+// releaseWorkers concurrent requests, otherwise the pages are fetched one after another until a page
+// is not full. Paging stops early at the limits set with WithMaxReleasePages and
+// WithMinReleaseVersion.
+func (c *Client) walkReleases(ctx context.Context, fn func([]Release) error) error {
+	releases, full, last, err := c.fetchReleasePage(ctx, 1)
+	if err != nil {
+		return err
+	}
+	releases, more := c.limitReleases(releases)
+	if err := fn(releases); err != nil {
+		return err
+	}
+	if c.maxPages > 0 && last > c.maxPages {
+		last = c.maxPages
+	}
+
+	// The pages can't be fetched ahead when a minimum version is set, because it is only known
+	// after a page has been read whether the next one is needed.
+	if last == 0 || c.minVersion != nil {
+		for page := 2; full && more && (c.maxPages <= 0 || page <= c.maxPages); page++ {
+			releases, full, _, err = c.fetchReleasePage(ctx, page)
+			if err != nil {
+				return err
+			}
+			releases, more = c.limitReleases(releases)
+			if err := fn(releases); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if last < 2 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Each page gets a buffered channel so that the workers never block on a page the caller has
+	// stopped waiting for.
+	results := make([]chan releasePage, last-1)
+	for i := range results {
+		results[i] = make(chan releasePage, 1)
+	}
+	pages := make(chan int)
+	go func() {
+		defer close(pages)
+		for page := 2; page <= last; page++ {
+			select {
+			case pages <- page:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	for i := 0; i < releaseWorkers && i < last-1; i++ {
+		go func() {
+			for page := range pages {
+				releases, _, _, err := c.fetchReleasePage(ctx, page)
+				results[page-2] <- releasePage{releases: releases, err: err}
+			}
+		}()
+	}
+
+	for _, result := range results {
+		page := <-result
+		if page.err != nil {
+			return page.err
+		}
+		if err := fn(page.releases); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// limitReleases drops the releases older than the minimum version set with WithMinReleaseVersion
+// from a page of the release list. It returns false as the second value if none of the releases on
+// the page were new enough, which means the following pages are not needed.
+func (c *Client) limitReleases(releases []Release) ([]Release, bool) {
+	if c.minVersion == nil {
+		return releases, true
+	}
+	limited := releases[:0]
+	for _, r := range releases {
+		if r.Version.GreaterThanOrEqual(c.minVersion) {
+			limited = append(limited, r)
+		}
+	}
+	return limited, len(limited) > 0
+}
+
+// fetchReleasePage fetches a page of the release list. It returns the releases on the page, whether
+// the page was full and the number of the last page from the Link header, or 0 if there is none.
+func (c *Client) fetchReleasePage(ctx context.Context, page int) ([]Release, bool, int, error) {
+	u := fmt.Sprintf("%s/releases?per_page=%d&page=%d", c.apiURL, releasesPerPage, page)
+	resp, err := c.do(ctx, u, true)
+	if err != nil {
+		return nil, false, 0, err
+	}
+	var items []githubRelease
+	err = json.NewDecoder(resp.Body).Decode(&items)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, false, 0, fmt.Errorf("decoding %s failed: %w", u, err)
+	}
+
+	releases := make([]Release, 0, len(items))
+	for _, item := range items {
+		if item.Draft {
+			continue
+		}
+		v, err := NewVersion(item.TagName)
+		if err != nil {
+			continue
+		}
+		releases = append(releases, Release{Version: v, Prerelease: item.Prerelease, PublishedAt: item.PublishedAt})
+	}
+
+	var last int
+	if link := linkURL(resp.Header, "last"); link != "" {
+		if lu, err := url.Parse(link); err == nil {
+			last, _ = strconv.Atoi(lu.Query().Get("page"))
+		}
+	}
+	return releases, len(items) >= releasesPerPage, last, nil
+}
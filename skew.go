@@ -0,0 +1,57 @@
+package version
+
+import "fmt"
+
+// SkewPolicy describes which version changes are allowed in a single upgrade step.
+type SkewPolicy struct {
+	// MaxMinors is the maximum number of minor versions a single upgrade can advance. Zero only
+	// allows patch and k0s build upgrades within the same release line.
+	MaxMinors int
+	// AllowMajor allows upgrading to the next major version. The number of minors in the previous
+	// major can't be known, so the first release line of the new major counts as one minor step and
+	// each following one as another (eg 1.31 to 2.1 is two minor steps). Skipping a major version is
+	// never allowed.
+	AllowMajor bool
+	// AllowPrerelease allows upgrading to prerelease versions.
+	AllowPrerelease bool
+	// AllowDowngrade allows the target version to be lower than the current version.
+	AllowDowngrade bool
+}
+
+// DefaultSkewPolicy allows upgrading one minor version at a time to non-prerelease versions.
+var DefaultSkewPolicy = SkewPolicy{MaxMinors: 1}
+
+// Validate returns nil if changing the version from one to the other is allowed by the policy.
+// Otherwise it returns an error wrapping ErrDowngrade or ErrSkewViolation.
+func (p SkewPolicy) Validate(from, to *Version) error {
+	if from == nil || to == nil {
+		return fmt.Errorf("%w: nil version", ErrInvalidVersion)
+	}
+
+	if to.IsPrerelease() && !p.AllowPrerelease {
+		return fmt.Errorf("%w: %s is a prerelease", ErrSkewViolation, to)
+	}
+
+	if to.LessThan(from) {
+		if !p.AllowDowngrade {
+			return fmt.Errorf("%w: %s is lower than %s", ErrDowngrade, to, from)
+		}
+		return nil
+	}
+
+	minors, ok := from.MinorsBetween(to)
+	if !ok {
+		if !p.AllowMajor {
+			return fmt.Errorf("%w: %s to %s is a major version upgrade", ErrSkewViolation, from, to)
+		}
+		if to.Major()-from.Major() > 1 {
+			return fmt.Errorf("%w: %s to %s skips a major version", ErrSkewViolation, from, to)
+		}
+		minors = to.Minor() + 1
+	}
+	if minors > p.MaxMinors {
+		return fmt.Errorf("%w: %s to %s advances %d minor versions (max %d)", ErrSkewViolation, from, to, minors, p.MaxMinors)
+	}
+
+	return nil
+}
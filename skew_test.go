@@ -0,0 +1,48 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/k0sproject/version"
+)
+
+func TestSkewPolicy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   version.SkewPolicy
+		from, to string
+		err      error
+	}{
+		{"patch", version.DefaultSkewPolicy, "1.28.1+k0s.0", "1.28.4+k0s.0", nil},
+		{"k0s build", version.DefaultSkewPolicy, "1.28.1+k0s.0", "1.28.1+k0s.1", nil},
+		{"same", version.DefaultSkewPolicy, "1.28.1+k0s.0", "1.28.1+k0s.0", nil},
+		{"one minor", version.DefaultSkewPolicy, "1.28.1+k0s.0", "1.29.0+k0s.0", nil},
+		{"two minors", version.DefaultSkewPolicy, "1.28.1+k0s.0", "1.30.0+k0s.0", version.ErrSkewViolation},
+		{"two minors allowed", version.SkewPolicy{MaxMinors: 2}, "1.28.1+k0s.0", "1.30.0+k0s.0", nil},
+		{"patch only", version.SkewPolicy{}, "1.28.1+k0s.0", "1.29.0+k0s.0", version.ErrSkewViolation},
+		{"downgrade", version.DefaultSkewPolicy, "1.28.1+k0s.1", "1.28.1+k0s.0", version.ErrDowngrade},
+		{"downgrade allowed", version.SkewPolicy{AllowDowngrade: true}, "1.29.1", "1.27.0", nil},
+		{"prerelease", version.DefaultSkewPolicy, "1.28.1", "1.29.0-rc.1", version.ErrSkewViolation},
+		{"prerelease allowed", version.SkewPolicy{MaxMinors: 1, AllowPrerelease: true}, "1.28.1", "1.29.0-rc.1", nil},
+		{"major", version.DefaultSkewPolicy, "1.31.1", "2.0.0", version.ErrSkewViolation},
+		{"major allowed", version.SkewPolicy{MaxMinors: 1, AllowMajor: true}, "1.31.1", "2.0.0", nil},
+		{"major and minor", version.SkewPolicy{MaxMinors: 1, AllowMajor: true}, "1.31.1", "2.1.0", version.ErrSkewViolation},
+		{"major and minor allowed", version.SkewPolicy{MaxMinors: 2, AllowMajor: true}, "1.31.1", "2.1.0", nil},
+		{"skipped major", version.SkewPolicy{MaxMinors: 1, AllowMajor: true}, "1.31.1", "3.0.0", version.ErrSkewViolation},
+		{"skipped major with many minors", version.SkewPolicy{MaxMinors: 100, AllowMajor: true}, "1.31.1", "3.0.0", version.ErrSkewViolation},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Validate(version.MustParse(tc.from), version.MustParse(tc.to))
+			if tc.err == nil {
+				NoError(t, err)
+				return
+			}
+			True(t, errors.Is(err, tc.err))
+		})
+	}
+
+	True(t, errors.Is(version.DefaultSkewPolicy.Validate(nil, version.MustParse("1.0.0")), version.ErrInvalidVersion))
+}
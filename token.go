@@ -0,0 +1,56 @@
+package version
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TokenSource provides the token used to authenticate requests to the GitHub API. It is asked for
+// a token before every API request, so implementations can hand out short-lived tokens such as
+// GitHub App installation tokens. An empty token makes the request unauthenticated.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc is a function that implements TokenSource.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token returns the token returned by the function.
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticToken is a TokenSource that always returns the same token.
+type StaticToken string
+
+// Token returns the token.
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// EnvToken returns a TokenSource that reads the token from the GH_TOKEN or GITHUB_TOKEN environment
+// variable, in the same order of preference as the GitHub CLI. The environment is read on every
+// call. It is the default token source of a Client.
+func EnvToken() TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		if token := os.Getenv("GH_TOKEN"); token != "" {
+			return token, nil
+		}
+		return os.Getenv("GITHUB_TOKEN"), nil
+	})
+}
+
+// TokenFile returns a TokenSource that reads the token from a file, such as a mounted secret. The
+// file is read on every call so that a rotated token is picked up, and surrounding whitespace is
+// trimmed.
+func TokenFile(path string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("read token file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	})
+}
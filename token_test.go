@@ -0,0 +1,81 @@
+package version_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/version"
+)
+
+func TestTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	NoError(t, os.WriteFile(path, []byte("file-token\n"), 0o600))
+
+	ts := version.TokenFile(path)
+	token, err := ts.Token(context.Background())
+	NoError(t, err)
+	Equal(t, "file-token", token)
+
+	NoError(t, os.WriteFile(path, []byte("rotated-token"), 0o600))
+	token, err = ts.Token(context.Background())
+	NoError(t, err)
+	Equal(t, "rotated-token", token)
+
+	_, err = version.TokenFile(filepath.Join(t.TempDir(), "missing")).Token(context.Background())
+	True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestEnvToken(t *testing.T) {
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+	token, err := version.EnvToken().Token(context.Background())
+	NoError(t, err)
+	Equal(t, "", token)
+
+	t.Setenv("GITHUB_TOKEN", "github-token")
+	token, err = version.EnvToken().Token(context.Background())
+	NoError(t, err)
+	Equal(t, "github-token", token)
+
+	t.Setenv("GH_TOKEN", "gh-token")
+	token, err = version.EnvToken().Token(context.Background())
+	NoError(t, err)
+	Equal(t, "gh-token", token)
+}
+
+func TestClientTokenSource(t *testing.T) {
+	server := newTestServer(t)
+	var auth string
+	observer := version.WithRequestObserver(func(req *http.Request, _ *http.Response, _ error, _ time.Duration) {
+		auth = req.Header.Get("Authorization")
+	})
+
+	calls := 0
+	ts := version.TokenSourceFunc(func(context.Context) (string, error) {
+		calls++
+		return "app-token", nil
+	})
+	c := newTestClient(server, observer, version.WithTokenSource(ts))
+	_, err := c.Releases(context.Background())
+	NoError(t, err)
+	Equal(t, "Bearer app-token", auth)
+	// one call per page
+	Equal(t, 2, calls)
+
+	c = newTestClient(server, observer, version.WithTokenSource(nil))
+	_, err = c.Releases(context.Background())
+	NoError(t, err)
+	Equal(t, "", auth)
+
+	errToken := errors.New("token expired")
+	c = newTestClient(server, version.WithTokenSource(version.TokenSourceFunc(func(context.Context) (string, error) {
+		return "", errToken
+	})))
+	_, err = c.Releases(context.Background())
+	True(t, errors.Is(err, errToken))
+}
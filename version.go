@@ -2,7 +2,7 @@
 package version
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"path/filepath"
 	"strconv"
@@ -13,8 +13,34 @@ const (
 	BaseUrl     = "https://github.com/k0sproject/k0s/"
 	k0s         = "k0s"
 	maxSegments = 3
+
+	// MaxVersionLength is the maximum length of a version string accepted by NewVersion.
+	MaxVersionLength = 256
+	// MaxIdentifierLength is the maximum length of a single dot, dash or plus separated part of a version string.
+	MaxIdentifierLength = 64
 )
 
+// LimitError is returned when the input to a parser exceeds one of the size limits. It wraps
+// ErrInvalidVersion or ErrInvalidConstraint depending on the parser.
+type LimitError struct {
+	// What describes the input that exceeded the limit (eg "version" or "identifier").
+	What   string
+	Length int
+	Limit  int
+	// Err is the sentinel error for the parser, ErrInvalidVersion or ErrInvalidConstraint.
+	Err error
+}
+
+// Error returns the error message.
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s too long (%d > %d)", e.What, e.Length, e.Limit)
+}
+
+// Unwrap returns the sentinel error for the parser.
+func (e *LimitError) Unwrap() error {
+	return e.Err
+}
+
 // this contains the fields that can be compared using go's equality operator
 type comparableFields struct {
 	// arrays (not slices) of basic types are comparable in go
@@ -33,18 +59,77 @@ type Version struct {
 	s string
 }
 
+// ParseOption is a functional option for NewVersionWithOptions.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	strict    bool
+	lowercase bool
+}
+
+// WithStrictSemver makes NewVersionWithOptions enforce the SemVer rules for numeric identifiers:
+// numeric segments and numeric prerelease identifiers can't have leading zeros (eg 1.02.3 or 1.2.3-rc.01).
+func WithStrictSemver() ParseOption {
+	return func(o *parseOptions) {
+		o.strict = true
+	}
+}
+
+// WithLowercase makes NewVersionWithOptions lowercase the input before parsing, so that versions like
+// v1.2.3-RC.1 from downstream forks are accepted and compare equal to v1.2.3-rc.1.
+func WithLowercase() ParseOption {
+	return func(o *parseOptions) {
+		o.lowercase = true
+	}
+}
+
 // NewVersion returns a new Version object from a string representation of a k0s version
 func NewVersion(v string) (*Version, error) {
+	return NewVersionWithOptions(v)
+}
+
+// NewVersionWithOptions is like NewVersion but accepts options that modify how the version is parsed.
+func NewVersionWithOptions(v string, opts ...ParseOption) (*Version, error) {
+	var options parseOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.lowercase {
+		v = strings.ToLower(v)
+	}
+
+	version, err := parse(v, options)
+	if err != nil {
+		return nil, err
+	}
+	// the string representation is computed up front so that String() never writes to a shared Version
+	version.s = version.format()
+	return version, nil
+}
+
+func parse(v string, options parseOptions) (*Version, error) {
 	if len(v) > 0 && v[0] == 'v' {
 		v = v[1:]
 	}
 	if v == "" {
-		return nil, errors.New("empty version")
+		return nil, fmt.Errorf("%w: empty version", ErrInvalidVersion)
 	}
+	if len(v) > MaxVersionLength {
+		return nil, &LimitError{What: "version", Length: len(v), Limit: MaxVersionLength, Err: ErrInvalidVersion}
+	}
+	var identifierLength int
 	for _, c := range v {
 		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '+' && c != '-' && c != '.' {
 			// version can only contain a-z, 0-9, +, -, .
-			return nil, fmt.Errorf("can't contain character %c", c)
+			return nil, fmt.Errorf("%w: can't contain character %c", ErrInvalidVersion, c)
+		}
+		if c == '+' || c == '-' || c == '.' {
+			identifierLength = 0
+			continue
+		}
+		identifierLength++
+		if identifierLength > MaxIdentifierLength {
+			return nil, &LimitError{What: "identifier", Length: identifierLength, Limit: MaxIdentifierLength, Err: ErrInvalidVersion}
 		}
 	}
 	idx := strings.IndexAny(v, "-+")
@@ -55,14 +140,17 @@ func NewVersion(v string) (*Version, error) {
 	}
 	segments := strings.Split(v, ".")
 	if len(segments) > maxSegments {
-		return nil, fmt.Errorf("too many segments (%d > %d", len(segments), maxSegments)
+		return nil, fmt.Errorf("%w: too many segments (%d > %d)", ErrInvalidVersion, len(segments), maxSegments)
 	}
 
 	version := &Version{comparableFields: comparableFields{numSegments: len(segments)}}
 	for idx, s := range segments {
 		segment, err := strconv.ParseUint(s, 10, 32)
 		if err != nil {
-			return nil, fmt.Errorf("parsing segment '%s': %w", s, err)
+			return nil, wrapCause(ErrInvalidVersion, err, "%s: parsing segment '%s': %v", ErrInvalidVersion, s, err)
+		}
+		if options.strict && hasLeadingZero(s) {
+			return nil, fmt.Errorf("%w: segment '%s' has a leading zero", ErrInvalidVersion, s)
 		}
 		version.segments[idx] = int(segment)
 	}
@@ -88,6 +176,14 @@ func NewVersion(v string) (*Version, error) {
 		}
 	}
 
+	if options.strict && version.pre != "" {
+		for _, identifier := range strings.Split(version.pre, ".") {
+			if isNumeric(identifier) && hasLeadingZero(identifier) {
+				return nil, fmt.Errorf("%w: prerelease identifier '%s' has a leading zero", ErrInvalidVersion, identifier)
+			}
+		}
+	}
+
 	if plusIndex == -1 {
 		return version, nil
 	}
@@ -120,11 +216,42 @@ func NewVersion(v string) (*Version, error) {
 	return version, nil
 }
 
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func hasLeadingZero(s string) bool {
+	return len(s) > 1 && s[0] == '0'
+}
+
 // Segments returns the numerical segments of the k0s version (eg 1.2.3 from v1.2.3).
 func (v *Version) Segments() []int {
 	return v.segments[:v.numSegments]
 }
 
+// Major returns the major version number (eg 1 from v1.2.3). It is 0 if the version has no segments.
+func (v *Version) Major() int {
+	return v.segments[0]
+}
+
+// Minor returns the minor version number (eg 2 from v1.2.3). It is 0 if the version has less than two segments.
+func (v *Version) Minor() int {
+	return v.segments[1]
+}
+
+// Patch returns the patch version number (eg 3 from v1.2.3). It is 0 if the version has less than three segments.
+func (v *Version) Patch() int {
+	return v.segments[2]
+}
+
 // Prerelease returns the prerelease part of the k0s version (eg rc1 from v1.2.3-rc1).
 func (v *Version) Prerelease() string {
 	return v.pre
@@ -147,7 +274,7 @@ func (v *Version) Base() string {
 
 // Clone returns a copy of the k0s version
 func (v *Version) Clone() *Version {
-	return &Version{comparableFields: v.comparableFields}
+	return &Version{comparableFields: v.comparableFields, s: v.s}
 }
 
 // WithK0s returns a copy of the k0s version with the k0s part set to the supplied value
@@ -155,6 +282,18 @@ func (v *Version) WithK0s(n int) *Version {
 	newV := v.Clone()
 	newV.isK0s = true
 	newV.k0s = n
+	newV.s = newV.format()
+	return newV
+}
+
+// KubernetesVersion returns a copy of the version without the k0s and metadata parts, which is the
+// version of the bundled kubernetes (eg v1.29.2 from v1.29.2+k0s.0)
+func (v *Version) KubernetesVersion() *Version {
+	newV := v.Clone()
+	newV.isK0s = false
+	newV.k0s = 0
+	newV.meta = ""
+	newV.s = newV.format()
 	return newV
 }
 
@@ -190,6 +329,10 @@ func (v *Version) String() string {
 	if v.s != "" {
 		return v.s
 	}
+	return v.format()
+}
+
+func (v *Version) format() string {
 	if v.numSegments == 0 {
 		return ""
 	}
@@ -221,15 +364,14 @@ func (v *Version) String() string {
 		sb.WriteString(v.meta)
 	}
 
-	v.s = sb.String()
-	return v.s
+	return sb.String()
 }
 
-// Equal returns true if the k0s version is equal to the supplied version
+// Equal returns true if the k0s version is equal to the supplied version. Two nil versions are equal,
+// a nil version is not equal to a non-nil one.
 func (v *Version) Equal(b *Version) bool {
 	if v == nil || b == nil {
-		// nil versions are not equal
-		return false
+		return v == nil && b == nil
 	}
 
 	if v.s != "" && b.s != "" {
@@ -241,8 +383,20 @@ func (v *Version) Equal(b *Version) bool {
 	return v.comparableFields == b.comparableFields
 }
 
-// Compare returns 0 if the k0s version is equal to the supplied version, 1 if it's greater and -1 if it's lower
+// Compare returns 0 if the k0s version is equal to the supplied version, 1 if it's greater and -1 if it's lower.
+// A nil version is lower than any non-nil version and equal to another nil version, so that collections
+// containing nil entries still have a total order.
 func (v *Version) Compare(b *Version) int {
+	if v == nil || b == nil {
+		switch {
+		case v == b:
+			return 0
+		case v == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
 	if v.Equal(b) {
 		return 0
 	}
@@ -293,6 +447,80 @@ func (v *Version) Compare(b *Version) int {
 	return 0
 }
 
+// CompareOption is a functional option for CompareWith.
+type CompareOption func(*compareOptions)
+
+type compareOptions struct {
+	metadataPrecedence bool
+}
+
+// WithMetadataPrecedence makes CompareWith consider the metadata part when the versions otherwise
+// have equal precedence. The dot-separated metadata identifiers are compared one by one, numerically
+// when both are numeric and lexically otherwise. A shorter metadata with otherwise equal identifiers
+// is lower.
+func WithMetadataPrecedence() CompareOption {
+	return func(o *compareOptions) {
+		o.metadataPrecedence = true
+	}
+}
+
+// CompareWith is like Compare but accepts options that modify how the versions are compared.
+func (v *Version) CompareWith(b *Version, opts ...CompareOption) int {
+	var options compareOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if c := v.Compare(b); c != 0 || !options.metadataPrecedence || v == nil || b == nil {
+		return c
+	}
+	return compareIdentifiers(v.meta, b.meta)
+}
+
+// compareIdentifiers compares two dot-separated identifier lists the way semver compares prereleases.
+func compareIdentifiers(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return -1
+	}
+	if b == "" {
+		return 1
+	}
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNum, aErr := strconv.ParseUint(aParts[i], 10, 64)
+		bNum, bErr := strconv.ParseUint(bParts[i], 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				if aNum < bNum {
+					return -1
+				}
+				return 1
+			}
+		case aErr == nil:
+			// numeric identifiers have lower precedence than alphanumeric ones
+			return -1
+		case bErr == nil:
+			return 1
+		case aParts[i] != bParts[i]:
+			if aParts[i] < bParts[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(aParts) < len(bParts):
+		return -1
+	case len(aParts) > len(bParts):
+		return 1
+	}
+	return 0
+}
+
 func (v *Version) urlString() string {
 	return strings.ReplaceAll(v.String(), "+", "%2B")
 }
@@ -315,6 +543,12 @@ func (v *Version) DownloadURL(os, arch string) string {
 	return v.assetBaseURL() + fmt.Sprintf("k0s-%s-%s%s", v.String(), arch, ext)
 }
 
+// CheckDownloadable verifies that the k0s binary for the os and arch exists for the k0s version using
+// the package level HTTP configuration. It returns an error wrapping ErrNotFound if it doesn't.
+func (v *Version) CheckDownloadable(ctx context.Context, os, arch string) error {
+	return DefaultClient().CheckDownloadable(ctx, v, os, arch)
+}
+
 // AirgapDownloadURL returns the k0s airgap bundle download URL for the k0s version
 func (v *Version) AirgapDownloadURL(arch string) string {
 	return v.assetBaseURL() + fmt.Sprintf("k0s-airgap-bundle-%s-%s", v.String(), arch)
@@ -350,6 +584,17 @@ func (v *Version) MarshalText() ([]byte, error) {
 	return []byte(v.String()), nil
 }
 
+// AppendText implements the encoding.TextAppender interface introduced in go 1.24.
+func (v *Version) AppendText(b []byte) ([]byte, error) {
+	return append(b, v.String()...), nil
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface introduced in go 1.24. The binary
+// form is the same as the text form.
+func (v *Version) AppendBinary(b []byte) ([]byte, error) {
+	return v.AppendText(b)
+}
+
 // UnmarshalText implements the encoding.TextUnmarshaler interface (used as fallback by encoding/json and yaml.v3).
 func (v *Version) UnmarshalText(text []byte) error {
 	if len(text) == 0 {
@@ -365,6 +610,16 @@ func (v *Version) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// GobEncode implements the gob.GobEncoder interface.
+func (v *Version) GobEncode() ([]byte, error) {
+	return v.MarshalText()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (v *Version) GobDecode(data []byte) error {
+	return v.UnmarshalText(data)
+}
+
 // MarshalYAML implements the yaml.v2 Marshaler interface.
 func (v *Version) MarshalYAML() (interface{}, error) {
 	if v == nil || v.numSegments == 0 {
@@ -392,6 +647,11 @@ func (v *Version) Satisfies(constraint Constraints) bool {
 	return constraint.Check(v)
 }
 
+// Is returns true if the version is matched by the supplied matcher
+func (v *Version) Is(m VersionMatcher) bool {
+	return m.Check(v)
+}
+
 // MustParse is like NewVersion but panics if the version cannot be parsed.
 // It simplifies safe initialization of global variables.
 func MustParse(v string) *Version {
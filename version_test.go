@@ -1,9 +1,13 @@
 package version_test
 
 import (
+	"bytes"
+	"encoding/gob"
 	"encoding/json"
 	"errors"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/k0sproject/version"
@@ -64,6 +68,67 @@ func TestNewVersion(t *testing.T) {
 	Error(t, err)
 }
 
+func TestStrictSemver(t *testing.T) {
+	for _, valid := range []string{"1.2.3", "0.0.0", "1.10.0-rc.10", "1.2.3-rc.0", "1.2.3-0a.01b", "1.2.3+k0s.0.01"} {
+		_, err := version.NewVersionWithOptions(valid, version.WithStrictSemver())
+		NoError(t, err)
+	}
+	for _, invalid := range []string{"1.02.3", "01.2.3", "1.2.03", "1.2.3-rc.01", "1.2.3-00"} {
+		_, err := version.NewVersion(invalid)
+		NoError(t, err)
+		_, err = version.NewVersionWithOptions(invalid, version.WithStrictSemver())
+		True(t, errors.Is(err, version.ErrInvalidVersion))
+	}
+}
+
+func TestLowercase(t *testing.T) {
+	_, err := version.NewVersion("v1.2.3-RC.1")
+	Error(t, err)
+
+	v, err := version.NewVersionWithOptions("V1.2.3-RC.1+K0S.1", version.WithLowercase())
+	NoError(t, err)
+	Equal(t, "v1.2.3-rc.1+k0s.1", v.String())
+	True(t, v.Equal(version.MustParse("v1.2.3-rc.1+k0s.1")))
+	True(t, v.IsK0s())
+}
+
+func TestInputLimits(t *testing.T) {
+	var limitErr *version.LimitError
+
+	_, err := version.NewVersion("1.2.3-" + strings.Repeat("a.", version.MaxVersionLength))
+	True(t, errors.As(err, &limitErr))
+	Equal(t, "version", limitErr.What)
+	True(t, errors.Is(err, version.ErrInvalidVersion))
+
+	_, err = version.NewVersion("1.2.3-" + strings.Repeat("a", version.MaxIdentifierLength+1))
+	True(t, errors.As(err, &limitErr))
+	Equal(t, "identifier", limitErr.What)
+
+	_, err = version.NewVersion("1.2.3-" + strings.Repeat("a", version.MaxIdentifierLength))
+	NoError(t, err)
+
+	_, err = version.NewConstraint(strings.Repeat(">= 1.0.0, ", version.MaxConstraintLength))
+	True(t, errors.As(err, &limitErr))
+	Equal(t, "constraint", limitErr.What)
+	True(t, errors.Is(err, version.ErrInvalidConstraint))
+	False(t, errors.Is(err, version.ErrInvalidVersion))
+
+	limitErr = &version.LimitError{What: "constraint string", Length: 2, Limit: 1, Err: version.ErrInvalidConstraint}
+	True(t, errors.Is(limitErr, version.ErrInvalidConstraint))
+}
+
+func TestNumericAccessors(t *testing.T) {
+	v := version.MustParse("1.23.4+k0s.1")
+	Equal(t, 1, v.Major())
+	Equal(t, 23, v.Minor())
+	Equal(t, 4, v.Patch())
+
+	v = version.MustParse("1.23")
+	Equal(t, 1, v.Major())
+	Equal(t, 23, v.Minor())
+	Equal(t, 0, v.Patch())
+}
+
 func TestWithK0s(t *testing.T) {
 	v, err := version.NewVersion("1.23.3+k0s.1")
 	NoError(t, err)
@@ -94,6 +159,15 @@ func TestWithK0s(t *testing.T) {
 	False(t, ok)
 }
 
+func TestKubernetesVersion(t *testing.T) {
+	v := version.MustParse("v1.29.2-rc.1+k0s.0.abc")
+	k := v.KubernetesVersion()
+	Equal(t, "v1.29.2-rc.1", k.String())
+	False(t, k.IsK0s())
+	// ensure original didnt change
+	Equal(t, "v1.29.2-rc.1+k0s.0.abc", v.String())
+}
+
 func TestBasicComparison(t *testing.T) {
 	a, err := version.NewVersion("1.23.1+k0s.1")
 	NoError(t, err)
@@ -116,6 +190,65 @@ func TestK0sComparison(t *testing.T) {
 	False(t, b.Equal(a))
 }
 
+func TestCompareWithMetadataPrecedence(t *testing.T) {
+	a := version.MustParse("1.23.1+k0s.1.build.9")
+	b := version.MustParse("1.23.1+k0s.1.build.10")
+	Equal(t, 0, a.Compare(b))
+	Equal(t, 0, a.CompareWith(b))
+	Equal(t, -1, a.CompareWith(b, version.WithMetadataPrecedence()))
+	Equal(t, 1, b.CompareWith(a, version.WithMetadataPrecedence()))
+	Equal(t, 0, a.CompareWith(a.Clone(), version.WithMetadataPrecedence()))
+
+	// precedence without metadata is not affected
+	Equal(t, 1, version.MustParse("1.23.2+a").CompareWith(version.MustParse("1.23.1+b"), version.WithMetadataPrecedence()))
+
+	testCases := []struct{ lower, higher string }{
+		{"1.0.0", "1.0.0+1"},
+		{"1.0.0+1", "1.0.0+2"},
+		{"1.0.0+2", "1.0.0+10"},
+		{"1.0.0+10", "1.0.0+abc"},
+		{"1.0.0+abc", "1.0.0+abd"},
+		{"1.0.0+k0s.1.abc", "1.0.0+k0s.1.abc.1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.lower+" < "+tc.higher, func(t *testing.T) {
+			Equal(t, -1, version.MustParse(tc.lower).CompareWith(version.MustParse(tc.higher), version.WithMetadataPrecedence()))
+			Equal(t, 1, version.MustParse(tc.higher).CompareWith(version.MustParse(tc.lower), version.WithMetadataPrecedence()))
+		})
+	}
+}
+
+func TestCompareWithMetadataPrecedenceNil(t *testing.T) {
+	var a, b *version.Version
+	v := version.MustParse("1.0.0+k0s.1")
+
+	Equal(t, 0, a.CompareWith(b, version.WithMetadataPrecedence()))
+	Equal(t, -1, a.CompareWith(v, version.WithMetadataPrecedence()))
+	Equal(t, 1, v.CompareWith(b, version.WithMetadataPrecedence()))
+}
+
+func TestNilComparison(t *testing.T) {
+	var a, b *version.Version
+	v := version.MustParse("1.0.0")
+
+	Equal(t, 0, a.Compare(b))
+	True(t, a.Equal(b))
+	Equal(t, -1, a.Compare(v))
+	Equal(t, 1, v.Compare(a))
+	False(t, a.Equal(v))
+	False(t, v.Equal(a))
+	True(t, a.LessThan(v))
+	True(t, v.GreaterThan(nil))
+	True(t, a.LessThanOrEqual(b))
+
+	c := version.Collection{v, nil, version.MustParse("0.1.0"), nil}
+	sorted := c.Sorted()
+	True(t, sorted[0] == nil)
+	True(t, sorted[1] == nil)
+	Equal(t, "v0.1.0", sorted[2].String())
+	Equal(t, "v1.0.0", sorted[3].String())
+}
+
 func TestSatisfies(t *testing.T) {
 	v, err := version.NewVersion("1.23.1+k0s.1")
 	NoError(t, err)
@@ -206,6 +339,51 @@ func TestUnmarshalling(t *testing.T) {
 	})
 }
 
+func TestAppenders(t *testing.T) {
+	// the encoding.TextAppender and BinaryAppender interfaces are only available in go 1.24+
+	type textAppender interface {
+		AppendText(b []byte) ([]byte, error)
+	}
+	type binaryAppender interface {
+		AppendBinary(b []byte) ([]byte, error)
+	}
+
+	var v interface{} = version.MustParse("v1.29.2+k0s.0")
+
+	ta, ok := v.(textAppender)
+	True(t, ok)
+	b, err := ta.AppendText([]byte("version: "))
+	NoError(t, err)
+	Equal(t, "version: v1.29.2+k0s.0", string(b))
+
+	ba, ok := v.(binaryAppender)
+	True(t, ok)
+	b, err = ba.AppendBinary(nil)
+	NoError(t, err)
+	Equal(t, "v1.29.2+k0s.0", string(b))
+}
+
+func TestGob(t *testing.T) {
+	type payload struct {
+		Version  *version.Version
+		Versions version.Collection
+	}
+	in := payload{
+		Version:  version.MustParse("v1.29.2-rc.1+k0s.0"),
+		Versions: version.Collection{version.MustParse("v1.28.0+k0s.1"), version.MustParse("v1.29.0")},
+	}
+
+	var buf bytes.Buffer
+	NoError(t, gob.NewEncoder(&buf).Encode(in))
+
+	var out payload
+	NoError(t, gob.NewDecoder(&buf).Decode(&out))
+	Equal(t, "v1.29.2-rc.1+k0s.0", out.Version.String())
+	True(t, in.Version.Equal(out.Version))
+	Equal(t, 2, len(out.Versions))
+	Equal(t, "v1.28.0+k0s.1", out.Versions[0].String())
+}
+
 func TestFailingUnmarshalling(t *testing.T) {
 	t.Run("JSON", func(t *testing.T) {
 		var v version.Version
@@ -228,3 +406,33 @@ func TestFailingUnmarshalling(t *testing.T) {
 		Error(t, err)
 	})
 }
+
+func TestConcurrentString(t *testing.T) {
+	v := version.MustParse("v1.29.2+k0s.0")
+	var decoded version.Version
+	NoError(t, decoded.UnmarshalText([]byte("v1.29.2+k0s.0")))
+
+	// String must only read the precomputed string, which is what makes it safe for concurrent
+	// use. An allocation would mean it formats, and possibly caches, the string on demand.
+	for _, tv := range []*version.Version{v, v.Clone(), v.WithK0s(1), v.KubernetesVersion(), &decoded} {
+		allocs := testing.AllocsPerRun(10, func() { _ = tv.String() })
+		Equal(t, 0.0, allocs)
+	}
+
+	results := make(chan string, 20)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- v.String()
+			results <- v.WithK0s(1).String()
+		}()
+	}
+	wg.Wait()
+	close(results)
+	for s := range results {
+		True(t, s == "v1.29.2+k0s.0" || s == "v1.29.2+k0s.1")
+	}
+	Equal(t, "v1.29.2+k0s.0", v.String())
+}